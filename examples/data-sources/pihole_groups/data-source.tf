@@ -0,0 +1,2 @@
+# A data source to retrieve the existing groups.
+data "pihole_groups" "groups" {}
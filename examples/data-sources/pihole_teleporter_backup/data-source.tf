@@ -0,0 +1,8 @@
+# A data source to download a Teleporter backup archive, e.g. from a nightly Terraform run.
+data "pihole_teleporter_backup" "nightly" {
+  output_path = "${path.root}/backups/pihole-teleporter.zip"
+}
+
+output "backup_sha256" {
+  value = data.pihole_teleporter_backup.nightly.sha256
+}
@@ -0,0 +1,10 @@
+# A data source to retrieve the installed Pi-hole versions.
+data "pihole_version" "version" {}
+
+output "pihole_versions" {
+  value = {
+    core = data.pihole_version.version.core_version
+    web  = data.pihole_version.version.web_version
+    ftl  = data.pihole_version.version.ftl_version
+  }
+}
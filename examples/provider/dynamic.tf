@@ -3,7 +3,7 @@ provider "docker" {
 }
 
 resource "docker_image" "pihole" {
-  name = "pihole/pihole:2022.05"
+  name = "pihole/pihole:2025.03.0"
 }
 
 locals {
@@ -14,7 +14,7 @@ locals {
 resource "docker_container" "pihole" {
   image = docker_image.pihole.image_id
   name  = "pihole"
-  env   = ["WEBPASSWORD=${local.pihole_password}"]
+  env   = ["FTLCONF_webserver_api_password=${local.pihole_password}"]
 
   capabilities {
     add = ["NET_ADMIN"]
@@ -27,8 +27,8 @@ resource "docker_container" "pihole" {
 }
 
 provider "pihole" {
-  url       = local.pihole_url
-  api_token = sha256(sha256(local.pihole_password))
+  url      = local.pihole_url
+  password = local.pihole_password
 }
 
 resource "null_resource" "pihole_wait" {
@@ -37,7 +37,7 @@ resource "null_resource" "pihole_wait" {
   }
 
   provisioner "local-exec" {
-    command = "until curl -sS ${local.pihole_url}/admin/api.php 1>/dev/null ; do echo waiting for Pi-hole API && sleep 1 ; done"
+    command = "until curl -sS ${local.pihole_url}/api/auth 1>/dev/null ; do echo waiting for Pi-hole API && sleep 1 ; done"
   }
 }
 
@@ -14,13 +14,57 @@ provider "pihole" {
 provider "pihole" {
   url = "https://pihole.domain.com" # PIHOLE_URL
 
-  # Requires Pi-hole Web Interface >= 5.11.0
+  # Application password created under Settings > Web interface / API
   api_token = var.pihole_api_token # PIHOLE_API_TOKEN
 }
 
 provider "pihole" {
-  url = "https://pihole.domain.com"
+  url      = "https://pihole.domain.com" # PIHOLE_URL
+  password = var.pihole_password         # PIHOLE_PASSWORD
+
+  # Required when two-factor authentication is enabled
+  totp_secret = var.pihole_totp_secret # PIHOLE_TOTP_SECRET
+}
+
+provider "pihole" {
+  url      = "https://pihole.domain.com" # PIHOLE_URL
+  password = var.pihole_password         # PIHOLE_PASSWORD
+
+  # Reverse proxy requiring mutual TLS
+  ca_pem          = file("ca.pem")     # PIHOLE_CA_PEM
+  client_cert     = file("client.pem") # PIHOLE_CLIENT_CERT
+  client_key      = var.client_key     # PIHOLE_CLIENT_KEY
+  tls_server_name = "pihole.internal"  # PIHOLE_TLS_SERVER_NAME
+}
+
+provider "pihole" {
+  url      = "https://pihole.domain.com" # PIHOLE_URL
+  password = var.pihole_password         # PIHOLE_PASSWORD
+
+  # Go easy on a Pi-hole running on a Raspberry Pi
+  max_concurrent_requests = 2     # PIHOLE_MAX_CONCURRENT_REQUESTS
+  max_retries             = 8     # PIHOLE_MAX_RETRIES
+  retry_wait_max          = "1m"  # PIHOLE_RETRY_WAIT_MAX
+  request_timeout         = "30s" # PIHOLE_REQUEST_TIMEOUT
+}
+
+provider "pihole" {
+  url      = "http://pi.hole"    # PIHOLE_URL
+  password = var.pihole_password # PIHOLE_PASSWORD
+
+  # Reach Pi-hole through a SOCKS proxy, e.g. an SSH tunnel to a jump host
+  proxy_url = "socks5://localhost:1080" # PIHOLE_PROXY_URL
+
+  # Or through a local Unix socket, conflicts with proxy_url
+  # unix_socket = "/run/pihole/api.sock" # PIHOLE_UNIX_SOCKET
+}
+
+provider "pihole" {
+  password = var.pihole_password # PIHOLE_PASSWORD
 
-  # Pi-hole sets the API token to the admin password hashed twiced via SHA-256
-  api_token = sha256(sha256(var.pihole_password))
+  # Keep a redundant pair in sync, DNS records, CNAME records, domains, upstreams and reverse servers are applied to both
+  urls = [
+    "https://pihole-1.domain.com",
+    "https://pihole-2.domain.com",
+  ]
 }
@@ -0,0 +1,2 @@
+# Lists are imported by their type and address
+terraform import pihole_adlist.hosts block/https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts
@@ -0,0 +1,10 @@
+resource "pihole_adlist" "hosts" {
+  address = "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts"
+  comment = "StevenBlack unified hosts"
+}
+
+resource "pihole_adlist" "allow" {
+  address = "https://example.com/allowlist.txt"
+  type    = "allow"
+  groups  = [0]
+}
@@ -0,0 +1,2 @@
+# The blocking state is imported regardless of the passed ID
+terraform import pihole_blocking.blocking blocking
@@ -0,0 +1,10 @@
+variable "maintenance" {
+  type    = bool
+  default = false
+}
+
+# Disable blocking for an hour during maintenance windows, plan shows when blocking was left off otherwise
+resource "pihole_blocking" "blocking" {
+  enabled = !var.maintenance
+  timer   = var.maintenance ? 3600 : null
+}
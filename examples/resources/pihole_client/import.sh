@@ -0,0 +1,2 @@
+# Clients are imported by their identifier
+terraform import pihole_client.tablet 12:34:56:78:9A:BC
@@ -0,0 +1,14 @@
+resource "pihole_group" "kids" {
+  name = "kids"
+}
+
+resource "pihole_client" "tablet" {
+  client  = "12:34:56:78:9A:BC"
+  comment = "Kids' tablet"
+  groups  = [pihole_group.kids.id]
+}
+
+resource "pihole_client" "guest_vlan" {
+  client = "192.168.20.0/24"
+  groups = [0, pihole_group.kids.id]
+}
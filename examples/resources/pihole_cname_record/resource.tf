@@ -2,3 +2,9 @@ resource "pihole_cname_record" "record" {
   domain = "foo.com"
   target = "bar.com"
 }
+
+resource "pihole_cname_record" "failover" {
+  domain = "app.example.com"
+  target = "ingress.cluster-a.example.com"
+  ttl    = 30
+}
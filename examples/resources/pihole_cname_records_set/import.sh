@@ -0,0 +1,2 @@
+# All existing CNAME records are imported regardless of the passed ID
+terraform import pihole_cname_records_set.all cname_records
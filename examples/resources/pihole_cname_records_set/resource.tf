@@ -0,0 +1,12 @@
+resource "pihole_cname_records_set" "all" {
+  record {
+    domain = "foo.com"
+    target = "bar.com"
+  }
+
+  record {
+    domain = "baz.com"
+    target = "bar.com"
+    ttl    = 300
+  }
+}
@@ -0,0 +1,2 @@
+# Config values are imported by their dotted key, the current value is restored on destroy
+terraform import pihole_config.query_logging dns.queryLogging
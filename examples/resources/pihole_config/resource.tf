@@ -0,0 +1,9 @@
+resource "pihole_config" "query_logging" {
+  key   = "dns.queryLogging"
+  value = jsonencode(false)
+}
+
+resource "pihole_config" "privacy_level" {
+  key   = "misc.privacylevel"
+  value = jsonencode(1)
+}
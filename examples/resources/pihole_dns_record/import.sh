@@ -1 +1,2 @@
-terraform import pihole_dns_record.record foo.com
+# DNS records are imported by their domain and IP. A domain with a single record can be imported by its domain
+terraform import pihole_dns_record.record foo.com,127.0.0.1
@@ -2,3 +2,8 @@ resource "pihole_dns_record" "record" {
   domain = "foo.com"
   ip     = "127.0.0.1"
 }
+
+resource "pihole_dns_record" "record_v6" {
+  domain = "foo.com"
+  ip     = "fd00::1"
+}
@@ -0,0 +1,2 @@
+# All existing DNS records are imported regardless of the passed ID
+terraform import pihole_dns_records_set.all dns_records
@@ -0,0 +1,11 @@
+resource "pihole_dns_records_set" "all" {
+  record {
+    domain = "foo.com"
+    ip     = "127.0.0.1"
+  }
+
+  record {
+    domain = "bar.com"
+    ip     = "fd00::1"
+  }
+}
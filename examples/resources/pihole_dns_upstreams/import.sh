@@ -0,0 +1,2 @@
+# The upstream DNS servers are imported regardless of the passed ID
+terraform import pihole_dns_upstreams.upstreams dns_upstreams
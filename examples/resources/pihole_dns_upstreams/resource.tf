@@ -0,0 +1,8 @@
+# Forward to a local recursive resolver first, then to Quad9
+resource "pihole_dns_upstreams" "upstreams" {
+  upstreams = [
+    "127.0.0.1#5335",
+    "9.9.9.9",
+    "2620:fe::fe#53",
+  ]
+}
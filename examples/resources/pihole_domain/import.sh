@@ -0,0 +1,2 @@
+# Domains are imported by their type/kind/domain
+terraform import pihole_domain.allow allow/exact/example.com
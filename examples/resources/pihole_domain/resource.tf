@@ -0,0 +1,11 @@
+resource "pihole_domain" "allow" {
+  domain  = "example.com"
+  type    = "allow"
+  comment = "Managed by Terraform"
+}
+
+resource "pihole_domain" "deny_ads" {
+  domain = "(\\.|^)ads\\.example\\.com$"
+  type   = "deny"
+  kind   = "regex"
+}
@@ -0,0 +1,11 @@
+resource "pihole_adlist" "hosts" {
+  address = "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts"
+  comment = "StevenBlack unified hosts"
+}
+
+# Rebuild gravity whenever the adlists change
+resource "pihole_gravity_update" "gravity" {
+  triggers = {
+    adlists = join(",", [pihole_adlist.hosts.address])
+  }
+}
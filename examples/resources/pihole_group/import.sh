@@ -0,0 +1,2 @@
+# Groups are imported by their name or numeric ID
+terraform import pihole_group.kids kids
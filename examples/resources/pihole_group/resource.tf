@@ -0,0 +1,10 @@
+resource "pihole_group" "kids" {
+  name        = "kids"
+  description = "Stricter blocking profile for the kids' devices"
+}
+
+resource "pihole_domain" "homework" {
+  domain = "homework.example.com"
+  type   = "allow"
+  groups = [pihole_group.kids.id]
+}
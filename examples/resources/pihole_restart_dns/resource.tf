@@ -0,0 +1,15 @@
+resource "pihole_dns_records_set" "all" {
+  record {
+    domain = "foo.com"
+    ip     = "127.0.0.1"
+  }
+}
+
+# Restart the DNS resolver whenever the DNS records change
+resource "pihole_restart_dns" "restart" {
+  triggers = {
+    records = sha1(jsonencode(pihole_dns_records_set.all.record))
+  }
+
+  depends_on = [pihole_dns_records_set.all]
+}
@@ -0,0 +1 @@
+terraform import pihole_reverse_server.office 192.168.10.0/24
@@ -0,0 +1,13 @@
+# Forward reverse lookups and local names of each VLAN to its router
+resource "pihole_reverse_server" "office" {
+  cidr   = "192.168.10.0/24"
+  server = "192.168.10.1"
+  domain = "office.lan"
+}
+
+resource "pihole_reverse_server" "iot" {
+  cidr    = "192.168.20.0/24"
+  server  = "192.168.20.1#5353"
+  domain  = "iot.lan"
+  enabled = false
+}
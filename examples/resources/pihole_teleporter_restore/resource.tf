@@ -0,0 +1,22 @@
+# Seed a fresh Pi-hole with the groups and lists of a golden backup
+resource "pihole_teleporter_restore" "golden" {
+  archive_path = "${path.module}/golden.zip"
+
+  sections = [
+    "gravity.group",
+    "gravity.adlist",
+    "gravity.adlist_by_group",
+  ]
+
+  triggers = {
+    archive = filesha256("${path.module}/golden.zip")
+  }
+}
+
+# Per-record resources take over once the backup is restored
+resource "pihole_dns_record" "record" {
+  domain = "foo.com"
+  ip     = "127.0.0.1"
+
+  depends_on = [pihole_teleporter_restore.golden]
+}
@@ -0,0 +1,89 @@
+// Package api implements the Pi-hole v6 API endpoints which are not yet covered by the go-pihole client.
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// errorResponse is the error body returned by the Pi-hole API
+type errorResponse struct {
+	Error struct {
+		Key     string  `json:"key"`
+		Message string  `json:"message"`
+		Hint    *string `json:"hint"`
+	} `json:"error"`
+}
+
+// processedResponse describes the per item results of a Pi-hole API write request
+type processedResponse struct {
+	Processed *struct {
+		Errors []struct {
+			Item  string `json:"item"`
+			Error string `json:"error"`
+		} `json:"errors"`
+	} `json:"processed"`
+}
+
+// err returns the first processing error reported by the Pi-hole API, if any
+func (p processedResponse) err() error {
+	if p.Processed == nil || len(p.Processed.Errors) == 0 {
+		return nil
+	}
+
+	e := p.Processed.Errors[0]
+
+	return fmt.Errorf("failed to process %q: %s", e.Item, e.Error)
+}
+
+// unexpectedStatus returns an error for a response with an unexpected status code
+func unexpectedStatus(res *http.Response) error {
+	b, _ := io.ReadAll(res.Body)
+
+	var errRes errorResponse
+	if err := json.Unmarshal(b, &errRes); err == nil && errRes.Error.Message != "" {
+		if errRes.Error.Hint != nil && *errRes.Error.Hint != "" {
+			return fmt.Errorf("received unexpected status code %d: %s (%s)", res.StatusCode, errRes.Error.Message, *errRes.Error.Hint)
+		}
+
+		return fmt.Errorf("received unexpected status code %d: %s", res.StatusCode, errRes.Error.Message)
+	}
+
+	return fmt.Errorf("received unexpected status code %d %s", res.StatusCode, string(b))
+}
+
+// closeBody drains and closes a response body, releasing its concurrency slot before a follow-up request
+// is sent on the same call path
+func closeBody(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+}
+
+// createGroups returns the groups of a create request, which are omitted when empty so Pi-hole assigns the default group
+func createGroups(groups []int64) *[]int64 {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	return &groups
+}
+
+// updateGroups returns the groups of an update request, which are always sent so group assignments can be removed
+func updateGroups(groups []int64) *[]int64 {
+	if groups == nil {
+		groups = []int64{}
+	}
+
+	return &groups
+}
+
+// stringValue dereferences a nullable string returned by the Pi-hole API
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,300 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrorTOTPRequired is returned when Pi-hole requires a two-factor authentication code but no TOTP secret is configured
+	ErrorTOTPRequired = errors.New("Pi-hole requires two-factor authentication, configure a TOTP secret or use an application password")
+
+	// ErrorLoginFailed is returned when Pi-hole rejects the configured password
+	ErrorLoginFailed = errors.New("Pi-hole rejected the password")
+
+	// ErrorAPINotFound is returned when the URL does not serve the Pi-hole v6 API, e.g. for Pi-hole v5
+	ErrorAPINotFound = errors.New("Pi-hole API not found")
+)
+
+type authRequest struct {
+	Password string `json:"password"`
+	TOTP     *int   `json:"totp,omitempty"`
+}
+
+type authResponse struct {
+	Session struct {
+		Valid   bool   `json:"valid"`
+		TOTP    bool   `json:"totp"`
+		SID     string `json:"sid"`
+		Message string `json:"message"`
+	} `json:"session"`
+}
+
+// totpCode returns the RFC 6238 time based one-time password of a base32 encoded secret
+func totpCode(secret string, now time.Time) (int, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return 0, fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(now.Unix()/30))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return int(code % 1000000), nil
+}
+
+// login creates a session with the configured password, including a two-factor authentication code when a TOTP secret is configured
+func (c *Client) login(ctx context.Context) (string, error) {
+	body := authRequest{Password: c.password}
+
+	if c.totpSecret != "" {
+		code, err := totpCode(c.totpSecret, time.Now())
+		if err != nil {
+			return "", err
+		}
+
+		body.TOTP = &code
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/auth", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+
+	for key, header := range c.headers {
+		req.Header[key] = header
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.base.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		err := unexpectedStatus(res)
+		if c.totpSecret == "" && strings.Contains(strings.ToLower(err.Error()), "2fa") {
+			return "", ErrorTOTPRequired
+		}
+
+		switch res.StatusCode {
+		case http.StatusUnauthorized:
+			return "", fmt.Errorf("%w: %w", ErrorLoginFailed, err)
+		case http.StatusNotFound:
+			return "", fmt.Errorf("%w: %w", ErrorAPINotFound, err)
+		default:
+			return "", fmt.Errorf("failed to login: %w", err)
+		}
+	}
+
+	var authRes authResponse
+	if err := json.NewDecoder(res.Body).Decode(&authRes); err != nil {
+		return "", fmt.Errorf("%w: failed to parse login body: %w", ErrorAPINotFound, err)
+	}
+
+	if !authRes.Session.Valid {
+		if authRes.Session.TOTP && c.totpSecret == "" {
+			return "", ErrorTOTPRequired
+		}
+
+		return "", fmt.Errorf("failed to login: %s", authRes.Session.Message)
+	}
+
+	return authRes.Session.SID, nil
+}
+
+// validSession reports whether a session ID, e.g. from the session cache, is still valid
+func (c *Client) validSession(ctx context.Context, sid string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/auth", nil)
+	if err != nil {
+		return false
+	}
+
+	for key, header := range c.headers {
+		req.Header[key] = header
+	}
+
+	req.Header.Set(authHeader, sid)
+
+	res, err := c.base.Do(req)
+	if err != nil {
+		return false
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return false
+	}
+
+	var authRes authResponse
+	if err := json.NewDecoder(res.Body).Decode(&authRes); err != nil {
+		return false
+	}
+
+	return authRes.Session.Valid
+}
+
+// session returns the session ID used to authenticate requests. A valid cached session is reused,
+// otherwise the client logs in and caches the new session.
+func (c *Client) session(ctx context.Context) (string, error) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.sid != "" {
+		return c.sid, nil
+	}
+
+	if c.cache != nil {
+		sid, err := c.cache.Get(c.baseURL)
+		if err != nil {
+			log.Printf("[WARN] Failed to read session cache: %s", err)
+		}
+
+		if sid != "" && c.validSession(ctx, sid) {
+			c.sid = sid
+			return c.sid, nil
+		}
+	}
+
+	sid, err := c.login(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	c.sid = sid
+	c.created = true
+
+	if c.cache != nil {
+		if err := c.cache.Set(c.baseURL, sid); err != nil {
+			log.Printf("[WARN] Failed to write session cache: %s", err)
+		}
+	}
+
+	return c.sid, nil
+}
+
+// invalidate drops the session ID after it was rejected, unless a concurrent request already replaced it
+func (c *Client) invalidate(sid string) {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.sid == sid {
+		c.sid = ""
+	}
+}
+
+// Logout deletes the session created by the client. Cached sessions are kept so later provider runs can reuse them.
+func (c *Client) Logout(ctx context.Context) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.sid == "" || !c.created || c.cache != nil {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+"/api/auth", nil)
+	if err != nil {
+		return err
+	}
+
+	for key, header := range c.headers {
+		req.Header[key] = header
+	}
+
+	req.Header.Set(authHeader, c.sid)
+
+	res, err := c.base.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	c.sid = ""
+
+	switch res.StatusCode {
+	case http.StatusNoContent, http.StatusOK, http.StatusUnauthorized, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to logout: %w", unexpectedStatus(res))
+	}
+}
+
+// authTransport authenticates all requests, including the ones sent by the go-pihole client, with the client's session.
+// Requests rejected with a 401 status, e.g. after the session expired, are retried once with a new session.
+type authTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/api/auth") {
+		return t.base.RoundTrip(req)
+	}
+
+	sid, err := t.client.session(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.base.RoundTrip(withSession(req, sid))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+
+	res.Body.Close()
+	t.client.invalidate(sid)
+
+	if sid, err = t.client.session(req.Context()); err != nil {
+		return nil, err
+	}
+
+	retry := withSession(req, sid)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.base.RoundTrip(retry)
+}
+
+// withSession returns a copy of the request authenticated with the session ID.
+// go-pihole sets the header with its non canonical key, so both keys are replaced.
+func withSession(req *http.Request, sid string) *http.Request {
+	req = req.Clone(req.Context())
+	delete(req.Header, authHeader)
+	req.Header.Set(authHeader, sid)
+
+	return req
+}
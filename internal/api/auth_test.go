@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 SHA-1 test vectors, truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	cases := map[int64]int{
+		59:         287082,
+		1111111109: 81804,
+		1234567890: 5924,
+		2000000000: 279037,
+	}
+
+	for unix, expected := range cases {
+		code, err := totpCode(secret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code != expected {
+			t.Fatalf("expected code %06d at %d, got %06d", expected, unix, code)
+		}
+	}
+
+	if _, err := totpCode("not base32!", time.Now()); err == nil {
+		t.Fatal("expected invalid secret error")
+	}
+}
+
+// testAuthServer requires a TOTP code at login and a session on all other requests
+type testAuthServer struct{}
+
+func (testAuthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/auth" {
+		var body authRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(w, `{"error":{"key":"unauthorized","message":"Unauthorized"}}`)
+			return
+		}
+
+		if body.TOTP == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"error":{"key":"bad_request","message":"No 2FA token found in JSON payload"}}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"session":{"valid":true,"totp":true,"sid":"sid"}}`)
+		return
+	}
+
+	if r.Header.Get(authHeader) != "sid" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(configBody("dns/hosts", []string{"127.0.0.1 foo.com"}))
+}
+
+func TestLoginTOTP(t *testing.T) {
+	client := newTestClient(t, testAuthServer{})
+	client.sid = ""
+	client.password = "secret"
+
+	if _, err := NewLocalDNSAPI(client).List(context.Background()); !errors.Is(err, ErrorTOTPRequired) {
+		t.Fatalf("expected TOTP required error, got %v", err)
+	}
+
+	client.totpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	records, err := NewLocalDNSAPI(client).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 1 || records[0] != (pihole.DNSRecord{IP: "127.0.0.1", Domain: "foo.com"}) {
+		t.Fatalf("unexpected records %v", records)
+	}
+}
+
+// testSessionServer issues sessions at login and requires a known session on all other requests
+type testSessionServer struct {
+	mu       sync.Mutex
+	sessions map[string]bool
+	logins   int
+}
+
+func (s *testSessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sid := r.Header.Get(authHeader)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/auth":
+		s.logins++
+		sid = fmt.Sprintf("sid-%d", s.logins)
+		s.sessions[sid] = true
+
+		_, _ = fmt.Fprintf(w, `{"session":{"valid":true,"sid":%q}}`, sid)
+	case !s.sessions[sid]:
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = fmt.Fprint(w, `{"session":{"valid":false}}`)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/auth":
+		_, _ = fmt.Fprint(w, `{"session":{"valid":true}}`)
+	case r.Method == http.MethodDelete && r.URL.Path == "/api/auth":
+		delete(s.sessions, sid)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		_ = json.NewEncoder(w).Encode(configBody("dns/hosts", []string{}))
+	}
+}
+
+func TestSessionRelogin(t *testing.T) {
+	server := &testSessionServer{sessions: map[string]bool{}}
+	client := newTestClient(t, server)
+	client.sid = ""
+
+	if _, err := client.GetConfigArray(context.Background(), "dns/hosts"); err != nil {
+		t.Fatal(err)
+	}
+
+	// expire the session
+	server.mu.Lock()
+	server.sessions = map[string]bool{}
+	server.mu.Unlock()
+
+	if _, err := client.GetConfigArray(context.Background(), "dns/hosts"); err != nil {
+		t.Fatal(err)
+	}
+
+	if server.logins != 2 {
+		t.Fatalf("expected 2 logins, got %d", server.logins)
+	}
+
+	if err := client.Logout(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(server.sessions) != 0 {
+		t.Fatalf("expected session to be logged out, found %v", server.sessions)
+	}
+}
+
+func TestSessionCache(t *testing.T) {
+	server := &testSessionServer{sessions: map[string]bool{}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	cache := NewSessionCache(filepath.Join(t.TempDir(), "sessions.json"))
+
+	for i := 0; i < 2; i++ {
+		client, err := New(Config{
+			Config: pihole.Config{
+				BaseURL:    httpServer.URL,
+				HttpClient: httpServer.Client(),
+			},
+			SessionCache: cache,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetConfigArray(context.Background(), "dns/hosts"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := client.Logout(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if server.logins != 1 {
+		t.Fatalf("expected cached session to be reused, got %d logins", server.logins)
+	}
+
+	if len(server.sessions) != 1 {
+		t.Fatal("expected cached session not to be logged out")
+	}
+}
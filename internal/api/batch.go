@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// batchWindow is how long a batch waits for further operations before it is flushed
+	batchWindow = 50 * time.Millisecond
+)
+
+// configBatcher coalesces concurrent reads and updates of a config array into a single
+// read and a single write per batch
+type configBatcher struct {
+	client *Client
+	path   string
+
+	mu      sync.Mutex
+	pending []*configOp
+	running bool
+}
+
+// configOp is a queued read, when update is nil, or read-modify-write of a config array
+type configOp struct {
+	ctx    context.Context
+	update func([]string) ([]string, error)
+	done   chan configResult
+}
+
+type configResult struct {
+	list []string
+	err  error
+}
+
+// batcher returns the batcher of the config array path, creating it if needed
+func (c *Client) batcher(path string) *configBatcher {
+	c.batchersMu.Lock()
+	defer c.batchersMu.Unlock()
+
+	if c.batchers == nil {
+		c.batchers = map[string]*configBatcher{}
+	}
+
+	b, ok := c.batchers[path]
+	if !ok {
+		b = &configBatcher{client: c, path: path}
+		c.batchers[path] = b
+	}
+
+	return b
+}
+
+// do queues the operation and waits for the result of the batch it is flushed with
+func (b *configBatcher) do(ctx context.Context, update func([]string) ([]string, error)) ([]string, error) {
+	op := &configOp{ctx: ctx, update: update, done: make(chan configResult, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, op)
+	if !b.running {
+		b.running = true
+		go b.run()
+	}
+	b.mu.Unlock()
+
+	select {
+	case res := <-op.done:
+		return res.list, res.err
+	case <-ctx.Done():
+		b.cancel(op)
+		return nil, ctx.Err()
+	}
+}
+
+// cancel removes a cancelled operation from the queue, so it is not written once its caller gave up
+func (b *configBatcher) cancel(op *configOp) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, pending := range b.pending {
+		if pending == op {
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// batchContext returns a context which is done once the contexts of all operations are done, so a batch
+// is abandoned when all of its callers gave up or timed out but not when only some of them did
+func batchContext(ops []*configOp) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		for _, op := range ops {
+			select {
+			case <-op.ctx.Done():
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		cancel()
+	}()
+
+	return ctx, cancel
+}
+
+// run flushes queued operations until the queue is empty
+func (b *configBatcher) run() {
+	for {
+		time.Sleep(batchWindow)
+
+		b.mu.Lock()
+		ops := b.pending
+		b.pending = nil
+		if len(ops) == 0 {
+			b.running = false
+			b.mu.Unlock()
+			return
+		}
+		b.mu.Unlock()
+
+		ctx, cancel := batchContext(ops)
+		b.flush(ctx, ops)
+		cancel()
+	}
+}
+
+// flush reads the config array once, applies all updates in order and writes the result once.
+// When the combined write is rejected, updates are retried one by one so a single invalid
+// update does not fail the whole batch. Operations cancelled after they were dequeued are dropped.
+func (b *configBatcher) flush(ctx context.Context, ops []*configOp) {
+	active := make([]*configOp, 0, len(ops))
+	for _, op := range ops {
+		if err := op.ctx.Err(); err != nil {
+			op.done <- configResult{err: err}
+			continue
+		}
+
+		active = append(active, op)
+	}
+
+	if ops = active; len(ops) == 0 {
+		return
+	}
+
+	list, err := b.client.GetConfigArray(ctx, b.path)
+	if err != nil {
+		for _, op := range ops {
+			op.done <- configResult{err: err}
+		}
+		return
+	}
+
+	errs := make([]error, len(ops))
+	updates := 0
+
+	for i, op := range ops {
+		if op.update == nil {
+			continue
+		}
+
+		updated, err := op.update(append([]string(nil), list...))
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		list = updated
+		updates++
+	}
+
+	if updates > 0 {
+		if err := b.client.PatchConfig(ctx, b.path, list); err != nil {
+			if updates > 1 {
+				for _, op := range ops {
+					b.flush(ctx, []*configOp{op})
+				}
+				return
+			}
+
+			for i := range errs {
+				if errs[i] == nil {
+					errs[i] = err
+				}
+			}
+		}
+	}
+
+	for i, op := range ops {
+		op.done <- configResult{list: list, err: errs[i]}
+	}
+}
+
+// ReadConfigArray returns a config array by its slash separated path, sharing the request with concurrent reads and updates
+func (c *Client) ReadConfigArray(ctx context.Context, path string) ([]string, error) {
+	return c.batcher(path).do(ctx, nil)
+}
+
+// UpdateConfigArray applies the update function to the current config array and writes the result back.
+// Concurrent updates of the same path are batched into a single read and write, and the written array is returned.
+func (c *Client) UpdateConfigArray(ctx context.Context, path string, update func([]string) ([]string, error)) ([]string, error) {
+	return c.batcher(path).do(ctx, update)
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+// testConfigServer serves the dns/hosts config array, rejecting writes which contain an invalid IP
+type testConfigServer struct {
+	mu      sync.Mutex
+	hosts   []string
+	gets    int
+	patches int
+}
+
+func (s *testConfigServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/config/dns/hosts":
+		s.gets++
+
+		_ = json.NewEncoder(w).Encode(configBody("dns/hosts", s.hosts))
+	case r.Method == http.MethodPatch && r.URL.Path == "/api/config":
+		s.patches++
+
+		var body struct {
+			Config struct {
+				DNS struct {
+					Hosts []string `json:"hosts"`
+				} `json:"dns"`
+			} `json:"config"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		for _, entry := range body.Config.DNS.Hosts {
+			if strings.HasPrefix(entry, "invalid") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprint(w, `{"error":{"key":"bad_request","message":"Invalid IP"}}`)
+				return
+			}
+		}
+
+		s.hosts = body.Config.DNS.Hosts
+
+		_ = json.NewEncoder(w).Encode(configBody("dns/hosts", s.hosts))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := New(Config{
+		Config: pihole.Config{
+			BaseURL:    server.URL,
+			SessionID:  "test",
+			HttpClient: server.Client(),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestConfigBatcherCoalescesWrites(t *testing.T) {
+	server := &testConfigServer{hosts: []string{}}
+	dns := NewLocalDNSAPI(newTestClient(t, server))
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = dns.Create(context.Background(), fmt.Sprintf("host%d.com", i), "127.0.0.1")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(server.hosts) != 20 {
+		t.Fatalf("expected 20 hosts, got %d", len(server.hosts))
+	}
+
+	if server.patches > 2 {
+		t.Fatalf("expected concurrent writes to be batched, got %d writes", server.patches)
+	}
+}
+
+func TestConfigBatcherIsolatesRejectedWrites(t *testing.T) {
+	server := &testConfigServer{hosts: []string{}}
+	dns := NewLocalDNSAPI(newTestClient(t, server))
+
+	var wg sync.WaitGroup
+	var validErr, invalidErr error
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, validErr = dns.Create(context.Background(), "foo.com", "127.0.0.1")
+	}()
+	go func() {
+		defer wg.Done()
+		_, invalidErr = dns.Create(context.Background(), "bar.com", "invalid")
+	}()
+	wg.Wait()
+
+	if validErr != nil {
+		t.Fatalf("expected valid write to succeed, got %s", validErr)
+	}
+
+	if invalidErr == nil {
+		t.Fatal("expected invalid write to fail")
+	}
+
+	if len(server.hosts) != 1 || server.hosts[0] != "127.0.0.1 foo.com" {
+		t.Fatalf("unexpected hosts %v", server.hosts)
+	}
+}
+
+func TestConfigBatcherDropsCancelledWrites(t *testing.T) {
+	server := &testConfigServer{hosts: []string{}}
+	client := newTestClient(t, server)
+	dns := NewLocalDNSAPI(client)
+
+	// A write cancelled while queued is removed from the queue
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := dns.Create(ctx, "foo.com", "127.0.0.1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancelled write to fail, got %v", err)
+	}
+
+	time.Sleep(3 * batchWindow)
+
+	if server.patches != 0 || len(server.hosts) != 0 {
+		t.Fatalf("expected cancelled write to be dropped, got %d writes and hosts %v", server.patches, server.hosts)
+	}
+
+	// A write cancelled after it was dequeued is dropped from its batch
+	cancelled := &configOp{ctx: ctx, update: func(hosts []string) ([]string, error) {
+		return append(hosts, "127.0.0.1 foo.com"), nil
+	}, done: make(chan configResult, 1)}
+	active := &configOp{ctx: context.Background(), update: func(hosts []string) ([]string, error) {
+		return append(hosts, "127.0.0.1 bar.com"), nil
+	}, done: make(chan configResult, 1)}
+
+	client.batcher(hostsPath).flush(context.Background(), []*configOp{cancelled, active})
+
+	if res := <-cancelled.done; !errors.Is(res.err, context.Canceled) {
+		t.Fatalf("expected cancelled write to fail, got %v", res.err)
+	}
+
+	if res := <-active.done; res.err != nil {
+		t.Fatalf("expected active write to succeed, got %s", res.err)
+	}
+
+	if len(server.hosts) != 1 || server.hosts[0] != "127.0.0.1 bar.com" {
+		t.Fatalf("unexpected hosts %v", server.hosts)
+	}
+}
+
+func TestBatchContext(t *testing.T) {
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	defer cancelSecond()
+
+	ctx, cancel := batchContext([]*configOp{{ctx: first}, {ctx: second}})
+	defer cancel()
+
+	cancelFirst()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("expected batch to continue while a caller is waiting")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancelSecond()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected batch to be cancelled once all callers gave up")
+	}
+}
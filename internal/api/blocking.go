@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+type BlockingAPI interface {
+	// Get the global blocking state.
+	Get(ctx context.Context) (*Blocking, error)
+
+	// Set the global blocking state. A timer greater than 0 reverts the state after the passed seconds.
+	Set(ctx context.Context, enabled bool, timer int) (*Blocking, error)
+}
+
+const (
+	BlockingEnabled  = "enabled"
+	BlockingDisabled = "disabled"
+)
+
+type blockingAPI struct {
+	client *Client
+}
+
+// NewBlockingAPI returns the global blocking API for the passed client
+func NewBlockingAPI(client *Client) BlockingAPI {
+	return &blockingAPI{client: client}
+}
+
+// Blocking is the global blocking state. Status is one of enabled, disabled, failed or unknown, Timer is
+// the number of seconds until the state reverts, or 0 when no timer is running.
+type Blocking struct {
+	Status string
+	Timer  int
+}
+
+// Enabled reports whether blocking is enabled
+func (b Blocking) Enabled() bool {
+	return b.Status == BlockingEnabled
+}
+
+type blockingResponse struct {
+	Blocking string   `json:"blocking"`
+	Timer    *float64 `json:"timer"`
+}
+
+type blockingRequest struct {
+	Blocking bool `json:"blocking"`
+	Timer    *int `json:"timer"`
+}
+
+func (res blockingResponse) toBlocking() *Blocking {
+	blocking := &Blocking{Status: res.Blocking}
+
+	if res.Timer != nil {
+		blocking.Timer = int(math.Ceil(*res.Timer))
+	}
+
+	return blocking
+}
+
+// Get returns the global blocking state
+func (b blockingAPI) Get(ctx context.Context) (*Blocking, error) {
+	res, err := b.client.Get(ctx, "/api/dns/blocking")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var blockingRes blockingResponse
+	if err := json.NewDecoder(res.Body).Decode(&blockingRes); err != nil {
+		return nil, fmt.Errorf("failed to parse blocking body: %w", err)
+	}
+
+	return blockingRes.toBlocking(), nil
+}
+
+// Set enables or disables blocking, reverting the state after the timer when it is greater than 0
+func (b blockingAPI) Set(ctx context.Context, enabled bool, timer int) (*Blocking, error) {
+	req := blockingRequest{Blocking: enabled}
+	if timer > 0 {
+		req.Timer = &timer
+	}
+
+	res, err := b.client.Post(ctx, "/api/dns/blocking", req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var blockingRes blockingResponse
+	if err := json.NewDecoder(res.Body).Decode(&blockingRes); err != nil {
+		return nil, fmt.Errorf("failed to parse blocking response body: %w", err)
+	}
+
+	return blockingRes.toBlocking(), nil
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+const (
+	authHeader = "X-FTL-SID"
+)
+
+// Config configures the Pi-hole client
+type Config struct {
+	pihole.Config
+
+	// TOTPSecret is the base32 encoded secret used to generate two-factor authentication codes at login
+	TOTPSecret string
+
+	// SessionCache, when set, is used to reuse sessions across provider runs
+	SessionCache *SessionCache
+}
+
+// Client extends the go-pihole client with requests it does not support, such as PATCH requests
+type Client struct {
+	*pihole.Client
+
+	baseURL    string
+	password   string
+	totpSecret string
+	headers    http.Header
+
+	// base sends unauthenticated requests, http authenticates requests with the client's session
+	base *http.Client
+	http *http.Client
+
+	sessionMu sync.Mutex
+	sid       string
+	cache     *SessionCache
+
+	// created is set when the session was created by the client's login rather than passed in or cached
+	created bool
+
+	// batchers coalesce concurrent reads and updates of config arrays, by config path
+	batchersMu sync.Mutex
+	batchers   map[string]*configBatcher
+
+	// detailed caches the detailed config describing the schema of all config values
+	detailedMu sync.Mutex
+	detailed   map[string]interface{}
+}
+
+// New returns a new Pi-hole client. Logins are handled by the client rather than by go-pihole,
+// so all requests share a single session which supports two-factor authentication.
+func New(config Config) (*Client, error) {
+	base := config.HttpClient
+	if base == nil {
+		base = retryablehttp.NewClient().StandardClient()
+	}
+
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(config.BaseURL, "/"),
+		password:   config.Password,
+		totpSecret: config.TOTPSecret,
+		headers:    config.Headers,
+		base:       base,
+		sid:        config.SessionID,
+		cache:      config.SessionCache,
+	}
+
+	authenticated := *base
+	authenticated.Transport = &authTransport{client: c, base: transport}
+	c.http = &authenticated
+
+	// go-pihole is given a placeholder session so it never logs in by itself, the transport sets the actual session
+	pc := config.Config
+	pc.HttpClient = c.http
+	pc.SessionID = "-"
+
+	client, err := pihole.New(pc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Client = client
+
+	return c, nil
+}
+
+// Patch sends an authenticated PATCH request
+func (c *Client) Patch(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, http.MethodPatch, path, bytes.NewBuffer(jsonData))
+}
+
+// do sends an authenticated request with a JSON body
+func (c *Client) do(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	return c.send(ctx, method, path, "application/json", body)
+}
+
+// send sends an authenticated request with a body of the passed content type
+func (c *Client) send(ctx context.Context, method string, path string, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, header := range c.headers {
+		req.Header[key] = header
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return c.http.Do(req)
+}
+
+// URL returns the base URL of the Pi-hole instance
+func (c *Client) URL() string {
+	return c.baseURL
+}
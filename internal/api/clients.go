@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type ClientAPI interface {
+	// List all configured clients.
+	List(ctx context.Context) (ClientEntryList, error)
+
+	// Get a client by its identifier.
+	Get(ctx context.Context, client string) (*ClientEntry, error)
+
+	// Create a client.
+	Create(ctx context.Context, client ClientEntry) (*ClientEntry, error)
+
+	// Update a client's comment and groups.
+	Update(ctx context.Context, client ClientEntry) (*ClientEntry, error)
+
+	// Delete a client by its identifier.
+	Delete(ctx context.Context, client string) error
+}
+
+var (
+	ErrorClientNotFound = errors.New("client not found")
+)
+
+type clientAPI struct {
+	client *Client
+}
+
+// NewClientAPI returns the client API for the passed Pi-hole client
+func NewClientAPI(client *Client) ClientAPI {
+	return &clientAPI{client: client}
+}
+
+type ClientEntry struct {
+	ID      int64
+	Client  string
+	Name    string
+	Comment string
+	Groups  []int64
+}
+
+type ClientEntryList []ClientEntry
+
+type clientResponse struct {
+	ID      int64   `json:"id"`
+	Client  string  `json:"client"`
+	Name    *string `json:"name"`
+	Comment *string `json:"comment"`
+	Groups  []int64 `json:"groups"`
+}
+
+type clientListResponse struct {
+	processedResponse
+	Clients []clientResponse `json:"clients"`
+}
+
+type clientRequest struct {
+	Client  string   `json:"client,omitempty"`
+	Comment string   `json:"comment"`
+	Groups  *[]int64 `json:"groups,omitempty"`
+}
+
+func (res clientListResponse) toClientList() ClientEntryList {
+	list := make(ClientEntryList, len(res.Clients))
+
+	for i, c := range res.Clients {
+		list[i] = ClientEntry{
+			ID:      c.ID,
+			Client:  c.Client,
+			Name:    stringValue(c.Name),
+			Comment: stringValue(c.Comment),
+			Groups:  c.Groups,
+		}
+	}
+
+	return list
+}
+
+// clientPath returns the API path of a single client
+func clientPath(client string) string {
+	return fmt.Sprintf("/api/clients/%s", url.PathEscape(client))
+}
+
+// List returns all configured clients
+func (c clientAPI) List(ctx context.Context) (ClientEntryList, error) {
+	res, err := c.client.Get(ctx, "/api/clients")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList clientListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse client list body: %w", err)
+	}
+
+	return resList.toClientList(), nil
+}
+
+// Get returns a client by its identifier
+func (c clientAPI) Get(ctx context.Context, client string) (*ClientEntry, error) {
+	res, err := c.client.Get(ctx, clientPath(client))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrorClientNotFound, client)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList clientListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse client body: %w", err)
+	}
+
+	for _, record := range resList.toClientList() {
+		if strings.EqualFold(record.Client, client) {
+			return &record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorClientNotFound, client)
+}
+
+// Create creates a client
+func (c clientAPI) Create(ctx context.Context, client ClientEntry) (*ClientEntry, error) {
+	res, err := c.client.Post(ctx, "/api/clients", clientRequest{
+		Client:  client.Client,
+		Comment: client.Comment,
+		Groups:  createGroups(client.Groups),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList clientListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse client response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return c.Get(ctx, client.Client)
+}
+
+// Update modifies a client's comment and groups
+func (c clientAPI) Update(ctx context.Context, client ClientEntry) (*ClientEntry, error) {
+	res, err := c.client.Put(ctx, clientPath(client.Client), clientRequest{
+		Comment: client.Comment,
+		Groups:  updateGroups(client.Groups),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList clientListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse client response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return c.Get(ctx, client.Client)
+}
+
+// Delete removes a client by its identifier
+func (c clientAPI) Delete(ctx context.Context, client string) error {
+	res, err := c.client.Delete(ctx, clientPath(client))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedStatus(res)
+	}
+}
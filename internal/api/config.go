@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrorConfigNotFound = errors.New("config path not found")
+
+	// ErrorConfigNotValue is returned when a config path refers to a group of config values, e.g. dns
+	ErrorConfigNotValue = errors.New("config path is not a config value")
+)
+
+type configResponse struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// configBody nests a value under its slash separated config path, e.g. dns/hosts
+func configBody(path string, value interface{}) map[string]interface{} {
+	keys := strings.Split(path, "/")
+
+	for i := len(keys) - 1; i >= 0; i-- {
+		value = map[string]interface{}{keys[i]: value}
+	}
+
+	return map[string]interface{}{"config": value}
+}
+
+// configValue looks up a value by its slash separated config path
+func configValue(config map[string]interface{}, path string) (interface{}, error) {
+	var value interface{} = config
+
+	for _, key := range strings.Split(path, "/") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrorConfigNotFound, path)
+		}
+
+		if value, ok = m[key]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrorConfigNotFound, path)
+		}
+	}
+
+	return value, nil
+}
+
+// ConfigDetails describes a config value in the server's config schema
+type ConfigDetails struct {
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Value       interface{} `json:"value"`
+	Default     interface{} `json:"default"`
+	Flags       struct {
+		RestartDNSMasq bool `json:"restart_dnsmasq"`
+		SessionReset   bool `json:"session_reset"`
+		EnvVar         bool `json:"env_var"`
+	} `json:"flags"`
+}
+
+// GetConfig returns a config value by its slash separated path, e.g. dns/queryLogging
+func (c *Client) GetConfig(ctx context.Context, path string) (interface{}, error) {
+	res, err := c.Get(ctx, fmt.Sprintf("/api/config/%s", path))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrorConfigNotFound, path)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var configRes configResponse
+	if err := json.NewDecoder(res.Body).Decode(&configRes); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s body: %w", path, err)
+	}
+
+	return configValue(configRes.Config, path)
+}
+
+// detailedConfig returns the detailed config of the server, which describes the schema of all config values. It is
+// read once and shared by all lookups of the client, as the schema does not change while the provider runs.
+func (c *Client) detailedConfig(ctx context.Context) (map[string]interface{}, error) {
+	c.detailedMu.Lock()
+	defer c.detailedMu.Unlock()
+
+	if c.detailed != nil {
+		return c.detailed, nil
+	}
+
+	res, err := c.Get(ctx, "/api/config?detailed=true")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var configRes configResponse
+	if err := json.NewDecoder(res.Body).Decode(&configRes); err != nil {
+		return nil, fmt.Errorf("failed to parse detailed config body: %w", err)
+	}
+
+	c.detailed = configRes.Config
+
+	return c.detailed, nil
+}
+
+// GetConfigDetails returns the schema of a config value by its slash separated path, looked up in the
+// detailed config of the server. The detailed config is cached, so the value of the details may be outdated,
+// use GetConfig to read the current value.
+func (c *Client) GetConfigDetails(ctx context.Context, path string) (*ConfigDetails, error) {
+	config, err := c.detailedConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := configValue(config, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Config values are described by objects with a type, groups of config values, e.g. dns, are not
+	m, ok := value.(map[string]interface{})
+	if _, leaf := m["type"].(string); !ok || !leaf {
+		return nil, fmt.Errorf("%w: %s", ErrorConfigNotValue, path)
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var details ConfigDetails
+	if err := json.Unmarshal(b, &details); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s details: %w", path, err)
+	}
+
+	return &details, nil
+}
+
+// GetConfigArray returns a config array by its slash separated path, e.g. dns/hosts
+func (c *Client) GetConfigArray(ctx context.Context, path string) ([]string, error) {
+	value, err := c.GetConfig(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config %s is not an array", path)
+	}
+
+	list := make([]string, len(items))
+	for i, item := range items {
+		if list[i], ok = item.(string); !ok {
+			return nil, fmt.Errorf("config %s contains a non string value", path)
+		}
+	}
+
+	return list, nil
+}
+
+// PatchConfig sets the config value of the slash separated path in a single request
+func (c *Client) PatchConfig(ctx context.Context, path string, value interface{}) error {
+	res, err := c.Patch(ctx, "/api/config", configBody(path, value))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return unexpectedStatus(res)
+	}
+
+	return nil
+}
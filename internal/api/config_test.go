@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetConfigDetails(t *testing.T) {
+	reads := 0
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("detailed") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		reads++
+
+		_, _ = fmt.Fprint(w, `{"config":{"dns":{
+			"queryLogging":{"description":"Log DNS queries","type":"boolean","value":true,"default":true,"flags":{"restart_dnsmasq":false,"session_reset":false,"env_var":false}},
+			"reply":{"host":{"force4":{"description":"Force IPv4","type":"boolean","value":false,"default":false,"flags":{}}}}
+		}}}`)
+	}))
+
+	details, err := client.GetConfigDetails(context.Background(), "dns/queryLogging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if details.Type != "boolean" || details.Value != true || details.Flags.EnvVar {
+		t.Fatalf("unexpected config details %+v", details)
+	}
+
+	if _, err := client.GetConfigDetails(context.Background(), "dns/reply"); !errors.Is(err, ErrorConfigNotValue) {
+		t.Fatalf("expected not a config value error, got %v", err)
+	}
+
+	if _, err := client.GetConfigDetails(context.Background(), "dns/unknown"); !errors.Is(err, ErrorConfigNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	if reads != 1 {
+		t.Fatalf("expected the detailed config to be read once, got %d reads", reads)
+	}
+}
+
+func TestGetConfigNotFound(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"error":{"key":"not_found","message":"Config path not found"}}`)
+	}))
+
+	if _, err := client.GetConfig(context.Background(), "dns/unknown"); !errors.Is(err, ErrorConfigNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
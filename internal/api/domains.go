@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type DomainAPI interface {
+	// List all allowed and denied domains.
+	List(ctx context.Context) (DomainList, error)
+
+	// Get a domain by its type, kind and name.
+	Get(ctx context.Context, domainType string, kind string, domain string) (*Domain, error)
+
+	// Create a domain.
+	Create(ctx context.Context, domain Domain) (*Domain, error)
+
+	// Update a domain, moving it to the domain's type and kind list when they differ from the passed type and kind.
+	Update(ctx context.Context, domainType string, kind string, domain Domain) (*Domain, error)
+
+	// Delete a domain by its type, kind and name.
+	Delete(ctx context.Context, domainType string, kind string, domain string) error
+}
+
+var (
+	ErrorDomainNotFound = errors.New("domain not found")
+)
+
+const (
+	DomainTypeAllow = "allow"
+	DomainTypeDeny  = "deny"
+
+	DomainKindExact = "exact"
+	DomainKindRegex = "regex"
+)
+
+type domainAPI struct {
+	client *Client
+}
+
+// NewDomainAPI returns the allow and deny list domain API for the passed client
+func NewDomainAPI(client *Client) DomainAPI {
+	return &domainAPI{client: client}
+}
+
+type Domain struct {
+	ID      int64
+	Domain  string
+	Type    string
+	Kind    string
+	Comment string
+	Groups  []int64
+	Enabled bool
+}
+
+type DomainList []Domain
+
+type domainResponse struct {
+	ID      int64   `json:"id"`
+	Domain  string  `json:"domain"`
+	Type    string  `json:"type"`
+	Kind    string  `json:"kind"`
+	Comment *string `json:"comment"`
+	Groups  []int64 `json:"groups"`
+	Enabled bool    `json:"enabled"`
+}
+
+type domainListResponse struct {
+	processedResponse
+	Domains []domainResponse `json:"domains"`
+}
+
+type domainRequest struct {
+	Domain  string   `json:"domain,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Kind    string   `json:"kind,omitempty"`
+	Comment string   `json:"comment"`
+	Groups  *[]int64 `json:"groups,omitempty"`
+	Enabled bool     `json:"enabled"`
+}
+
+func (res domainResponse) toDomain() Domain {
+	return Domain{
+		ID:      res.ID,
+		Domain:  res.Domain,
+		Type:    res.Type,
+		Kind:    res.Kind,
+		Comment: stringValue(res.Comment),
+		Groups:  res.Groups,
+		Enabled: res.Enabled,
+	}
+}
+
+func (res domainListResponse) toDomainList() DomainList {
+	list := make(DomainList, len(res.Domains))
+
+	for i, d := range res.Domains {
+		list[i] = d.toDomain()
+	}
+
+	return list
+}
+
+// domainPath returns the API path of a domain list, or of a single domain when one is passed
+func domainPath(domainType string, kind string, domain string) string {
+	path := fmt.Sprintf("/api/domains/%s/%s", url.PathEscape(domainType), url.PathEscape(kind))
+	if domain != "" {
+		path = fmt.Sprintf("%s/%s", path, url.PathEscape(domain))
+	}
+
+	return path
+}
+
+// List returns all allowed and denied domains
+func (d domainAPI) List(ctx context.Context) (DomainList, error) {
+	res, err := d.client.Get(ctx, "/api/domains")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList domainListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse domain list body: %w", err)
+	}
+
+	return resList.toDomainList(), nil
+}
+
+// Get returns a domain by its type, kind and name
+func (d domainAPI) Get(ctx context.Context, domainType string, kind string, domain string) (*Domain, error) {
+	res, err := d.client.Get(ctx, domainPath(domainType, kind, domain))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrorDomainNotFound, domain)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList domainListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse domain body: %w", err)
+	}
+
+	for _, record := range resList.toDomainList() {
+		if record.Domain == domain {
+			return &record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorDomainNotFound, domain)
+}
+
+// Create adds a domain to the allow or deny list of the domain's type and kind
+func (d domainAPI) Create(ctx context.Context, domain Domain) (*Domain, error) {
+	res, err := d.client.Post(ctx, domainPath(domain.Type, domain.Kind, ""), domainRequest{
+		Domain:  domain.Domain,
+		Comment: domain.Comment,
+		Groups:  createGroups(domain.Groups),
+		Enabled: domain.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList domainListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse domain response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return d.Get(ctx, domain.Type, domain.Kind, domain.Domain)
+}
+
+// Update modifies a domain, moving it between lists when its type or kind changed
+func (d domainAPI) Update(ctx context.Context, domainType string, kind string, domain Domain) (*Domain, error) {
+	res, err := d.client.Put(ctx, domainPath(domainType, kind, domain.Domain), domainRequest{
+		Type:    domain.Type,
+		Kind:    domain.Kind,
+		Comment: domain.Comment,
+		Groups:  updateGroups(domain.Groups),
+		Enabled: domain.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList domainListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse domain response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return d.Get(ctx, domain.Type, domain.Kind, domain.Domain)
+}
+
+// Delete removes a domain from the allow or deny list of the passed type and kind
+func (d domainAPI) Delete(ctx context.Context, domainType string, kind string, domain string) error {
+	res, err := d.client.Delete(ctx, domainPath(domainType, kind, domain))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedStatus(res)
+	}
+}
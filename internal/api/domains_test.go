@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDomainGroupsRequest(t *testing.T) {
+	var bodies []map[string]interface{}
+
+	domains := NewDomainAPI(newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			bodies = append(bodies, body)
+		}
+
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = fmt.Fprint(w, `{"domains":[{"id":1,"domain":"foo.com","type":"deny","kind":"exact","groups":[],"enabled":true}]}`)
+	})))
+
+	domain := Domain{Domain: "foo.com", Type: DomainTypeDeny, Kind: DomainKindExact, Enabled: true}
+
+	if _, err := domains.Create(context.Background(), domain); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := domains.Update(context.Background(), DomainTypeDeny, DomainKindExact, domain); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bodies))
+	}
+
+	// Pi-hole assigns the default group to created domains without groups
+	if _, ok := bodies[0]["groups"]; ok {
+		t.Fatalf("expected create request without groups, got %v", bodies[0])
+	}
+
+	// An empty list removes all group assignments on update
+	if groups, ok := bodies[1]["groups"].([]interface{}); !ok || len(groups) != 0 {
+		t.Fatalf("expected update request with empty groups, got %v", bodies[1])
+	}
+}
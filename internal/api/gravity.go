@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type GravityAPI interface {
+	// Update rebuilds the gravity database, passing each line of the gravity output to progress.
+	Update(ctx context.Context, progress func(line string)) error
+}
+
+type gravityAPI struct {
+	client *Client
+}
+
+// NewGravityAPI returns the gravity API for the passed client
+func NewGravityAPI(client *Client) GravityAPI {
+	return &gravityAPI{client: client}
+}
+
+// ansiEscape matches the terminal color and line erase sequences of the gravity output
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// gravityFailure marks failed steps in the gravity output
+const gravityFailure = "[✗]"
+
+// gravityLine returns the final text of a gravity output line, which overwrites its progress using carriage returns
+func gravityLine(line string) string {
+	line = ansiEscape.ReplaceAllString(line, "")
+
+	segments := strings.Split(line, "\r")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segment := strings.TrimRight(segments[i], " \t"); strings.TrimSpace(segment) != "" {
+			return segment
+		}
+	}
+
+	return ""
+}
+
+// Update runs gravity, streaming its output, and returns an error listing the failed steps, if any
+func (g gravityAPI) Update(ctx context.Context, progress func(line string)) error {
+	res, err := g.client.do(ctx, http.MethodPost, "/api/action/gravity", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return unexpectedStatus(res)
+	}
+
+	var failures []string
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		line := gravityLine(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		progress(line)
+
+		if strings.Contains(line, gravityFailure) {
+			failures = append(failures, strings.TrimSpace(line))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read gravity output: %w", err)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("gravity reported errors:\n%s", strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGravityUpdate(t *testing.T) {
+	output := "  [i] Neutrino emissions detected...\n" +
+		"  [i] Status: Pending...\r\x1b[K  [\x1b[1;32m✓\x1b[0m] Status: Retrieval successful\n" +
+		"  [\x1b[1;31m✗\x1b[0m] Status: Not found\n" +
+		"\n" +
+		"  [\x1b[1;32m✓\x1b[0m] Done.\n"
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/action/gravity" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, output)
+	}))
+
+	var lines []string
+	err := NewGravityAPI(client).Update(context.Background(), func(line string) {
+		lines = append(lines, line)
+	})
+
+	expected := []string{
+		"  [i] Neutrino emissions detected...",
+		"  [✓] Status: Retrieval successful",
+		"  [✗] Status: Not found",
+		"  [✓] Done.",
+	}
+
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected gravity output %q", lines)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "[✗] Status: Not found") {
+		t.Fatalf("expected gravity error, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type GroupAPI interface {
+	// List all groups.
+	List(ctx context.Context) (GroupList, error)
+
+	// Get a group by its ID.
+	Get(ctx context.Context, id int64) (*Group, error)
+
+	// GetByName gets a group by its name.
+	GetByName(ctx context.Context, name string) (*Group, error)
+
+	// Create a group.
+	Create(ctx context.Context, group Group) (*Group, error)
+
+	// Update a group by its current name, renaming it when the group's name differs.
+	Update(ctx context.Context, name string, group Group) (*Group, error)
+
+	// Delete a group by its name.
+	Delete(ctx context.Context, name string) error
+}
+
+var (
+	ErrorGroupNotFound = errors.New("group not found")
+)
+
+type groupAPI struct {
+	client *Client
+}
+
+// NewGroupAPI returns the group API for the passed client
+func NewGroupAPI(client *Client) GroupAPI {
+	return &groupAPI{client: client}
+}
+
+type Group struct {
+	ID      int64
+	Name    string
+	Comment string
+	Enabled bool
+}
+
+type GroupList []Group
+
+type groupResponse struct {
+	ID      int64   `json:"id"`
+	Name    string  `json:"name"`
+	Comment *string `json:"comment"`
+	Enabled bool    `json:"enabled"`
+}
+
+type groupListResponse struct {
+	processedResponse
+	Groups []groupResponse `json:"groups"`
+}
+
+type groupRequest struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+	Enabled bool   `json:"enabled"`
+}
+
+func (res groupListResponse) toGroupList() GroupList {
+	list := make(GroupList, len(res.Groups))
+
+	for i, g := range res.Groups {
+		list[i] = Group{
+			ID:      g.ID,
+			Name:    g.Name,
+			Comment: stringValue(g.Comment),
+			Enabled: g.Enabled,
+		}
+	}
+
+	return list
+}
+
+// List returns all groups
+func (g groupAPI) List(ctx context.Context) (GroupList, error) {
+	res, err := g.client.Get(ctx, "/api/groups")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList groupListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse group list body: %w", err)
+	}
+
+	return resList.toGroupList(), nil
+}
+
+// Get returns a group by its ID
+func (g groupAPI) Get(ctx context.Context, id int64) (*Group, error) {
+	list, err := g.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch groups: %w", err)
+	}
+
+	for _, group := range list {
+		if group.ID == id {
+			return &group, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrorGroupNotFound, id)
+}
+
+// GetByName returns a group by its name
+func (g groupAPI) GetByName(ctx context.Context, name string) (*Group, error) {
+	list, err := g.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch groups: %w", err)
+	}
+
+	for _, group := range list {
+		if group.Name == name {
+			return &group, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorGroupNotFound, name)
+}
+
+// Create creates a group
+func (g groupAPI) Create(ctx context.Context, group Group) (*Group, error) {
+	res, err := g.client.Post(ctx, "/api/groups", groupRequest{
+		Name:    group.Name,
+		Comment: group.Comment,
+		Enabled: group.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList groupListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse group response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return g.GetByName(ctx, group.Name)
+}
+
+// Update modifies the group with the passed name, renaming it if the group's name differs
+func (g groupAPI) Update(ctx context.Context, name string, group Group) (*Group, error) {
+	res, err := g.client.Put(ctx, fmt.Sprintf("/api/groups/%s", url.PathEscape(name)), groupRequest{
+		Name:    group.Name,
+		Comment: group.Comment,
+		Enabled: group.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList groupListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse group response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return g.GetByName(ctx, group.Name)
+}
+
+// Delete removes a group by its name
+func (g groupAPI) Delete(ctx context.Context, name string) error {
+	res, err := g.client.Delete(ctx, fmt.Sprintf("/api/groups/%s", url.PathEscape(name)))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedStatus(res)
+	}
+}
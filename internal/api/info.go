@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type InfoAPI interface {
+	// Version gets the versions of the installed Pi-hole components.
+	Version(ctx context.Context) (*Version, error)
+}
+
+var (
+	ErrorUnsupportedVersion = errors.New("unsupported Pi-hole version")
+)
+
+// MinimumMajorVersion is the first Pi-hole major version serving the REST API used by the provider
+const MinimumMajorVersion = 6
+
+type infoAPI struct {
+	client *Client
+}
+
+// NewInfoAPI returns the Pi-hole information API for the passed client
+func NewInfoAPI(client *Client) InfoAPI {
+	return &infoAPI{client: client}
+}
+
+// Version holds the locally installed versions of the Pi-hole components. Docker is only set for
+// Pi-hole containers.
+type Version struct {
+	Core   string
+	Web    string
+	FTL    string
+	Docker string
+}
+
+type versionComponentResponse struct {
+	Local struct {
+		Version *string `json:"version"`
+	} `json:"local"`
+}
+
+type versionResponse struct {
+	Version struct {
+		Core   versionComponentResponse `json:"core"`
+		Web    versionComponentResponse `json:"web"`
+		FTL    versionComponentResponse `json:"ftl"`
+		Docker struct {
+			Local *string `json:"local"`
+		} `json:"docker"`
+	} `json:"version"`
+}
+
+// Major returns the major version of Pi-hole core, e.g. 6 for v6.0.4. Development builds without a
+// semantic version return an error.
+func (v Version) Major() (int, error) {
+	major, _, _ := strings.Cut(strings.TrimPrefix(v.Core, "v"), ".")
+
+	n, err := strconv.Atoi(major)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected Pi-hole core version %q", v.Core)
+	}
+
+	return n, nil
+}
+
+// Version returns the versions of the installed Pi-hole components
+func (i infoAPI) Version(ctx context.Context) (*Version, error) {
+	res, err := i.client.Get(ctx, "/api/info/version")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var versionRes versionResponse
+	if err := json.NewDecoder(res.Body).Decode(&versionRes); err != nil {
+		return nil, fmt.Errorf("failed to parse version body: %w", err)
+	}
+
+	return &Version{
+		Core:   stringValue(versionRes.Version.Core.Local.Version),
+		Web:    stringValue(versionRes.Version.Web.Local.Version),
+		FTL:    stringValue(versionRes.Version.FTL.Local.Version),
+		Docker: stringValue(versionRes.Version.Docker.Local),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// limitTransport limits the number of concurrent requests, shared by all requests of the client
+type limitTransport struct {
+	base http.RoundTripper
+	sem  chan struct{}
+}
+
+// NewLimitTransport returns a transport allowing at most max concurrent requests. Slots are held until
+// the response body is read to EOF or closed.
+func NewLimitTransport(base http.RoundTripper, max int) http.RoundTripper {
+	return &limitTransport{
+		base: base,
+		sem:  make(chan struct{}, max),
+	}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		<-t.sem
+		return nil, err
+	}
+
+	res.Body = &limitBody{ReadCloser: res.Body, release: func() { <-t.sem }}
+
+	return res, nil
+}
+
+// limitBody releases the concurrency slot of its request once read to EOF or closed
+type limitBody struct {
+	io.ReadCloser
+
+	once    sync.Once
+	release func()
+}
+
+func (b *limitBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err == io.EOF {
+		b.once.Do(b.release)
+	}
+
+	return n, err
+}
+
+func (b *limitBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
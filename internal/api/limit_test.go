@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLimitTransport(t *testing.T) {
+	var current, peak int32
+
+	transport := NewLimitTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), 2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodGet, "http://pi.hole/api/info", nil)
+
+			res, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestLimitTransportFollowUpRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+		}
+
+		_, _ = fmt.Fprintln(w, `{"groups":[{"id":1,"name":"iot","enabled":true}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	httpClient := server.Client()
+	httpClient.Transport = NewLimitTransport(httpClient.Transport, 1)
+
+	client, err := New(Config{
+		Config: pihole.Config{
+			BaseURL:    server.URL,
+			SessionID:  "test",
+			HttpClient: httpClient,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// Create reads the created group with a follow-up request, which must not wait for the slot of the create request
+	group, err := NewGroupAPI(client).Create(ctx, Group{Name: "iot", Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if group.ID != 1 {
+		t.Fatalf("expected group 1, got %d", group.ID)
+	}
+}
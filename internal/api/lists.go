@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type ListAPI interface {
+	// List all subscribed lists.
+	List(ctx context.Context) (ListList, error)
+
+	// Get a subscribed list by its address and type. When no type is passed, the first list with a matching address is returned.
+	Get(ctx context.Context, address string, listType string) (*List, error)
+
+	// Create a subscribed list.
+	Create(ctx context.Context, list List) (*List, error)
+
+	// Update a subscribed list of the passed type, changing its type when the list's type differs.
+	Update(ctx context.Context, listType string, list List) (*List, error)
+
+	// Delete a subscribed list by its address and type.
+	Delete(ctx context.Context, address string, listType string) error
+}
+
+var (
+	ErrorListNotFound = errors.New("list not found")
+)
+
+const (
+	ListTypeBlock = "block"
+	ListTypeAllow = "allow"
+)
+
+type listAPI struct {
+	client *Client
+}
+
+// NewListAPI returns the subscribed list API for the passed client
+func NewListAPI(client *Client) ListAPI {
+	return &listAPI{client: client}
+}
+
+type List struct {
+	ID             int64
+	Address        string
+	Type           string
+	Comment        string
+	Groups         []int64
+	Enabled        bool
+	DateUpdated    time.Time
+	Number         int64
+	InvalidDomains int64
+	ABPEntries     int64
+	Status         int64
+}
+
+type ListList []List
+
+type listResponse struct {
+	ID             int64   `json:"id"`
+	Address        string  `json:"address"`
+	Type           string  `json:"type"`
+	Comment        *string `json:"comment"`
+	Groups         []int64 `json:"groups"`
+	Enabled        bool    `json:"enabled"`
+	DateUpdated    int64   `json:"date_updated"`
+	Number         int64   `json:"number"`
+	InvalidDomains int64   `json:"invalid_domains"`
+	ABPEntries     int64   `json:"abp_entries"`
+	Status         int64   `json:"status"`
+}
+
+type listListResponse struct {
+	processedResponse
+	Lists []listResponse `json:"lists"`
+}
+
+type listRequest struct {
+	Address string   `json:"address,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	Comment string   `json:"comment"`
+	Groups  *[]int64 `json:"groups,omitempty"`
+	Enabled bool     `json:"enabled"`
+}
+
+func (res listResponse) toList() List {
+	l := List{
+		ID:             res.ID,
+		Address:        res.Address,
+		Type:           res.Type,
+		Comment:        stringValue(res.Comment),
+		Groups:         res.Groups,
+		Enabled:        res.Enabled,
+		Number:         res.Number,
+		InvalidDomains: res.InvalidDomains,
+		ABPEntries:     res.ABPEntries,
+		Status:         res.Status,
+	}
+
+	if res.DateUpdated != 0 {
+		l.DateUpdated = time.Unix(res.DateUpdated, 0).UTC()
+	}
+
+	return l
+}
+
+func (res listListResponse) toListList() ListList {
+	list := make(ListList, len(res.Lists))
+
+	for i, l := range res.Lists {
+		list[i] = l.toList()
+	}
+
+	return list
+}
+
+// listPath returns the API path of a single list, filtered by type when one is passed
+func listPath(address string, listType string) string {
+	path := fmt.Sprintf("/api/lists/%s", url.PathEscape(address))
+	if listType != "" {
+		path = fmt.Sprintf("%s?type=%s", path, url.QueryEscape(listType))
+	}
+
+	return path
+}
+
+// List returns all subscribed lists
+func (l listAPI) List(ctx context.Context) (ListList, error) {
+	res, err := l.client.Get(ctx, "/api/lists")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList listListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse list body: %w", err)
+	}
+
+	return resList.toListList(), nil
+}
+
+// Get returns a subscribed list by its address and type
+func (l listAPI) Get(ctx context.Context, address string, listType string) (*List, error) {
+	res, err := l.client.Get(ctx, listPath(address, listType))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrorListNotFound, address)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList listListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse list body: %w", err)
+	}
+
+	for _, list := range resList.toListList() {
+		if list.Address == address && (listType == "" || list.Type == listType) {
+			return &list, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorListNotFound, address)
+}
+
+// Create subscribes to a list
+func (l listAPI) Create(ctx context.Context, list List) (*List, error) {
+	res, err := l.client.Post(ctx, fmt.Sprintf("/api/lists?type=%s", url.QueryEscape(list.Type)), listRequest{
+		Address: list.Address,
+		Comment: list.Comment,
+		Groups:  createGroups(list.Groups),
+		Enabled: list.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList listListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse list response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return l.Get(ctx, list.Address, list.Type)
+}
+
+// Update modifies a subscribed list of the passed type
+func (l listAPI) Update(ctx context.Context, listType string, list List) (*List, error) {
+	res, err := l.client.Put(ctx, listPath(list.Address, listType), listRequest{
+		Type:    list.Type,
+		Comment: list.Comment,
+		Groups:  updateGroups(list.Groups),
+		Enabled: list.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var resList listListResponse
+	if err := json.NewDecoder(res.Body).Decode(&resList); err != nil {
+		return nil, fmt.Errorf("failed to parse list response body: %w", err)
+	}
+
+	if err := resList.err(); err != nil {
+		return nil, err
+	}
+
+	closeBody(res)
+
+	return l.Get(ctx, list.Address, list.Type)
+}
+
+// Delete unsubscribes from a list by its address and type
+func (l listAPI) Delete(ctx context.Context, address string, listType string) error {
+	res, err := l.client.Delete(ctx, listPath(address, listType))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return unexpectedStatus(res)
+	}
+}
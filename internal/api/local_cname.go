@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+type LocalCNAMEAPI interface {
+	// List all CNAME records.
+	List(ctx context.Context) (pihole.CNAMERecordList, error)
+
+	// Get a CNAME record by its domain.
+	Get(ctx context.Context, domain string) (*pihole.CNAMERecord, error)
+
+	// Create a CNAME record. A TTL of 0 leaves the TTL unset.
+	Create(ctx context.Context, domain string, target string, ttl int) (*pihole.CNAMERecord, error)
+
+	// Update the target and TTL of a CNAME record in a single write.
+	Update(ctx context.Context, domain string, target string, ttl int) (*pihole.CNAMERecord, error)
+
+	// Delete a CNAME record by its domain.
+	Delete(ctx context.Context, domain string) error
+
+	// Set replaces all CNAME records in a single write.
+	Set(ctx context.Context, records pihole.CNAMERecordList) (pihole.CNAMERecordList, error)
+
+	// Remove deletes the passed CNAME records in a single write, keeping all other records.
+	Remove(ctx context.Context, records pihole.CNAMERecordList) error
+}
+
+type localCNAMEAPI struct {
+	client *Client
+}
+
+// NewLocalCNAMEAPI returns the CNAME API for the passed client, which batches concurrent writes into config array updates
+func NewLocalCNAMEAPI(client *Client) LocalCNAMEAPI {
+	return &localCNAMEAPI{client: client}
+}
+
+// cnameRecordsPath is the config path of the local CNAME records
+const cnameRecordsPath = "dns/cnameRecords"
+
+// cnameEntry returns the "domain,target[,ttl]" dns.cnameRecords entry of a CNAME record
+func cnameEntry(domain string, target string, ttl int) string {
+	entry := fmt.Sprintf("%s,%s", domain, target)
+	if ttl != 0 {
+		entry = fmt.Sprintf("%s,%d", entry, ttl)
+	}
+
+	return entry
+}
+
+// List returns all CNAME records
+func (cname localCNAMEAPI) List(ctx context.Context) (pihole.CNAMERecordList, error) {
+	records, err := cname.client.ReadConfigArray(ctx, cnameRecordsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCNAMERecords(records), nil
+}
+
+// parseCNAMERecords returns the CNAME records of a list of "domain,target[,ttl]" entries
+func parseCNAMERecords(records []string) pihole.CNAMERecordList {
+	list := make(pihole.CNAMERecordList, 0, len(records))
+
+	for _, entry := range records {
+		fields := strings.Split(entry, ",")
+		if len(fields) < 2 {
+			continue
+		}
+
+		record := pihole.CNAMERecord{
+			Domain: fields[0],
+			Target: fields[1],
+		}
+
+		if len(fields) == 3 {
+			record.TTL, _ = strconv.Atoi(fields[2])
+		}
+
+		list = append(list, record)
+	}
+
+	return list
+}
+
+// findCNAMERecord returns the CNAME record of the domain
+func findCNAMERecord(records pihole.CNAMERecordList, domain string) (*pihole.CNAMERecord, error) {
+	for _, record := range records {
+		if strings.EqualFold(record.Domain, domain) {
+			return &record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", pihole.ErrorLocalCNAMENotFound, domain)
+}
+
+// Get returns a CNAME record by its domain
+func (cname localCNAMEAPI) Get(ctx context.Context, domain string) (*pihole.CNAMERecord, error) {
+	records, err := cname.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch CNAME records: %w", err)
+	}
+
+	return findCNAMERecord(records, domain)
+}
+
+// Create creates a CNAME record, batched with concurrent writes of CNAME records
+func (cname localCNAMEAPI) Create(ctx context.Context, domain string, target string, ttl int) (*pihole.CNAMERecord, error) {
+	records, err := cname.client.UpdateConfigArray(ctx, cnameRecordsPath, func(records []string) ([]string, error) {
+		if _, err := findCNAMERecord(parseCNAMERecords(records), domain); err == nil {
+			return nil, fmt.Errorf("CNAME record %s already exists", domain)
+		}
+
+		return append(records, cnameEntry(domain, target, ttl)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findCNAMERecord(parseCNAMERecords(records), domain)
+}
+
+// Update replaces the target and TTL of the CNAME record of the domain within the CNAME list,
+// batched with concurrent writes of CNAME records
+func (cname localCNAMEAPI) Update(ctx context.Context, domain string, target string, ttl int) (*pihole.CNAMERecord, error) {
+	records, err := cname.client.UpdateConfigArray(ctx, cnameRecordsPath, func(records []string) ([]string, error) {
+		return replaceCNAME(records, domain, target, ttl)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findCNAMERecord(parseCNAMERecords(records), domain)
+}
+
+// replaceCNAME replaces the target and TTL of a domain within a list of "domain,target[,ttl]" entries
+func replaceCNAME(records []string, domain string, target string, ttl int) ([]string, error) {
+	for i, entry := range records {
+		fields := strings.Split(entry, ",")
+		if len(fields) < 2 || !strings.EqualFold(fields[0], domain) {
+			continue
+		}
+
+		records[i] = cnameEntry(fields[0], target, ttl)
+
+		return records, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", pihole.ErrorLocalCNAMENotFound, domain)
+}
+
+// Delete removes the CNAME record of the domain, batched with concurrent writes of CNAME records
+func (cname localCNAMEAPI) Delete(ctx context.Context, domain string) error {
+	_, err := cname.client.UpdateConfigArray(ctx, cnameRecordsPath, func(records []string) ([]string, error) {
+		return removeCNAME(records, domain), nil
+	})
+
+	return err
+}
+
+// removeCNAME removes the entry of a domain from a list of "domain,target[,ttl]" entries
+func removeCNAME(records []string, domain string) []string {
+	updated := make([]string, 0, len(records))
+
+	for _, entry := range records {
+		if fields := strings.Split(entry, ","); !strings.EqualFold(fields[0], domain) {
+			updated = append(updated, entry)
+		}
+	}
+
+	return updated
+}
+
+// Set replaces all CNAME records with the passed records, batched with concurrent writes of CNAME records
+func (cname localCNAMEAPI) Set(ctx context.Context, records pihole.CNAMERecordList) (pihole.CNAMERecordList, error) {
+	entries, err := cname.client.UpdateConfigArray(ctx, cnameRecordsPath, func([]string) ([]string, error) {
+		entries := make([]string, len(records))
+		for i, record := range records {
+			entries[i] = cnameEntry(record.Domain, record.Target, record.TTL)
+		}
+
+		return entries, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCNAMERecords(entries), nil
+}
+
+// Remove deletes the passed CNAME records, batched with concurrent writes of CNAME records
+func (cname localCNAMEAPI) Remove(ctx context.Context, records pihole.CNAMERecordList) error {
+	_, err := cname.client.UpdateConfigArray(ctx, cnameRecordsPath, func(entries []string) ([]string, error) {
+		for _, record := range records {
+			entries = removeCNAME(entries, record.Domain)
+		}
+
+		return entries, nil
+	})
+
+	return err
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+func TestReplaceCNAME(t *testing.T) {
+	cases := []struct {
+		records  []string
+		domain   string
+		target   string
+		ttl      int
+		expected []string
+	}{
+		{
+			records:  []string{"bar.com,baz.com", "foo.com,bar.com,300"},
+			domain:   "FOO.com",
+			target:   "woz.com",
+			ttl:      300,
+			expected: []string{"bar.com,baz.com", "foo.com,woz.com,300"},
+		},
+		{
+			records:  []string{"foo.com,bar.com,300"},
+			domain:   "foo.com",
+			target:   "bar.com",
+			ttl:      0,
+			expected: []string{"foo.com,bar.com"},
+		},
+		{
+			records:  []string{"foo.com,bar.com"},
+			domain:   "foo.com",
+			target:   "bar.com",
+			ttl:      60,
+			expected: []string{"foo.com,bar.com,60"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := replaceCNAME(c.records, c.domain, c.target, c.ttl)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, actual)
+		}
+	}
+
+	if _, err := replaceCNAME([]string{"bar.com,baz.com"}, "foo.com", "woz.com", 0); !errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestRemoveCNAME(t *testing.T) {
+	actual := removeCNAME([]string{"foo.com,bar.com,300", "baz.com,bar.com"}, "FOO.com")
+
+	expected := []string{"baz.com,bar.com"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
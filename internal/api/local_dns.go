@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+type LocalDNSAPI interface {
+	// List all DNS records.
+	List(ctx context.Context) (pihole.DNSRecordList, error)
+
+	// Get a DNS record by its domain and IP.
+	Get(ctx context.Context, domain string, ip string) (*pihole.DNSRecord, error)
+
+	// Create a DNS record.
+	Create(ctx context.Context, domain string, ip string) (*pihole.DNSRecord, error)
+
+	// Update the IP of a DNS record in a single write.
+	Update(ctx context.Context, domain string, ip string, newIP string) (*pihole.DNSRecord, error)
+
+	// Delete a DNS record by its domain and IP.
+	Delete(ctx context.Context, domain string, ip string) error
+
+	// Set replaces all DNS records in a single write.
+	Set(ctx context.Context, records pihole.DNSRecordList) (pihole.DNSRecordList, error)
+
+	// Remove deletes the passed DNS records in a single write, keeping all other records.
+	Remove(ctx context.Context, records pihole.DNSRecordList) error
+}
+
+type localDNSAPI struct {
+	client *Client
+}
+
+// NewLocalDNSAPI returns the local DNS API for the passed client, which unlike the go-pihole
+// LocalDNS API identifies records by both their domain and IP
+func NewLocalDNSAPI(client *Client) LocalDNSAPI {
+	return &localDNSAPI{client: client}
+}
+
+// hostsPath is the config path of the local DNS records
+const hostsPath = "dns/hosts"
+
+// SameIP reports whether two IP addresses are equal, ignoring differences in notation
+func SameIP(a string, b string) bool {
+	if ipA, ipB := net.ParseIP(a), net.ParseIP(b); ipA != nil && ipB != nil {
+		return ipA.Equal(ipB)
+	}
+
+	return strings.EqualFold(a, b)
+}
+
+// List returns all DNS records
+func (dns localDNSAPI) List(ctx context.Context) (pihole.DNSRecordList, error) {
+	hosts, err := dns.client.ReadConfigArray(ctx, hostsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseHosts(hosts), nil
+}
+
+// parseHosts returns a DNS record for each hostname of a list of "IP hostname [hostname...]" entries
+func parseHosts(hosts []string) pihole.DNSRecordList {
+	list := pihole.DNSRecordList{}
+
+	for _, entry := range hosts {
+		fields := strings.Fields(entry)
+		if len(fields) < 2 {
+			continue
+		}
+
+		for _, host := range fields[1:] {
+			list = append(list, pihole.DNSRecord{IP: fields[0], Domain: host})
+		}
+	}
+
+	return list
+}
+
+// findDNSRecord returns the DNS record matching both the domain and IP
+func findDNSRecord(records pihole.DNSRecordList, domain string, ip string) (*pihole.DNSRecord, error) {
+	for _, record := range records {
+		if strings.EqualFold(record.Domain, domain) && SameIP(record.IP, ip) {
+			return &record, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", pihole.ErrorLocalDNSNotFound, domain, ip)
+}
+
+// Get returns the DNS record matching both the domain and IP
+func (dns localDNSAPI) Get(ctx context.Context, domain string, ip string) (*pihole.DNSRecord, error) {
+	records, err := dns.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch custom DNS records: %w", err)
+	}
+
+	return findDNSRecord(records, domain, ip)
+}
+
+// Create creates a DNS record, batched with concurrent writes of DNS records
+func (dns localDNSAPI) Create(ctx context.Context, domain string, ip string) (*pihole.DNSRecord, error) {
+	hosts, err := dns.client.UpdateConfigArray(ctx, hostsPath, func(hosts []string) ([]string, error) {
+		if _, err := findDNSRecord(parseHosts(hosts), domain, ip); err == nil {
+			return nil, fmt.Errorf("custom DNS record %s %s already exists", domain, ip)
+		}
+
+		return append(hosts, fmt.Sprintf("%s %s", ip, domain)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findDNSRecord(parseHosts(hosts), domain, ip)
+}
+
+// Update replaces the IP of the DNS record matching the domain and IP within the hosts list,
+// batched with concurrent writes of DNS records
+func (dns localDNSAPI) Update(ctx context.Context, domain string, ip string, newIP string) (*pihole.DNSRecord, error) {
+	hosts, err := dns.client.UpdateConfigArray(ctx, hostsPath, func(hosts []string) ([]string, error) {
+		return replaceHostIP(hosts, domain, ip, newIP)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findDNSRecord(parseHosts(hosts), domain, newIP)
+}
+
+// replaceHostIP replaces the IP of a domain within a list of "IP hostname [hostname...]" entries.
+// The domain is split into its own entry when it shares the entry with other hostnames.
+func replaceHostIP(hosts []string, domain string, ip string, newIP string) ([]string, error) {
+	for i, entry := range hosts {
+		fields := strings.Fields(entry)
+		if len(fields) < 2 || !SameIP(fields[0], ip) {
+			continue
+		}
+
+		for j, host := range fields[1:] {
+			if !strings.EqualFold(host, domain) {
+				continue
+			}
+
+			if len(fields) == 2 {
+				hosts[i] = fmt.Sprintf("%s %s", newIP, host)
+				return hosts, nil
+			}
+
+			remaining := append(fields[:j+1:j+1], fields[j+2:]...)
+
+			updated := make([]string, 0, len(hosts)+1)
+			updated = append(updated, hosts[:i]...)
+			updated = append(updated, strings.Join(remaining, " "), fmt.Sprintf("%s %s", newIP, host))
+			updated = append(updated, hosts[i+1:]...)
+
+			return updated, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", pihole.ErrorLocalDNSNotFound, domain, ip)
+}
+
+// Delete removes the DNS record matching both the domain and IP, batched with concurrent writes of DNS records
+func (dns localDNSAPI) Delete(ctx context.Context, domain string, ip string) error {
+	_, err := dns.client.UpdateConfigArray(ctx, hostsPath, func(hosts []string) ([]string, error) {
+		return removeHost(hosts, domain, ip), nil
+	})
+
+	return err
+}
+
+// removeHost removes a domain from a list of "IP hostname [hostname...]" entries, dropping entries left without hostnames
+func removeHost(hosts []string, domain string, ip string) []string {
+	updated := make([]string, 0, len(hosts))
+
+	for _, entry := range hosts {
+		fields := strings.Fields(entry)
+		if len(fields) < 2 || !SameIP(fields[0], ip) {
+			updated = append(updated, entry)
+			continue
+		}
+
+		remaining := fields[:1]
+		for _, host := range fields[1:] {
+			if !strings.EqualFold(host, domain) {
+				remaining = append(remaining, host)
+			}
+		}
+
+		switch {
+		case len(remaining) == len(fields):
+			updated = append(updated, entry)
+		case len(remaining) > 1:
+			updated = append(updated, strings.Join(remaining, " "))
+		}
+	}
+
+	return updated
+}
+
+// Set replaces all DNS records with the passed records, batched with concurrent writes of DNS records
+func (dns localDNSAPI) Set(ctx context.Context, records pihole.DNSRecordList) (pihole.DNSRecordList, error) {
+	hosts, err := dns.client.UpdateConfigArray(ctx, hostsPath, func([]string) ([]string, error) {
+		hosts := make([]string, len(records))
+		for i, record := range records {
+			hosts[i] = fmt.Sprintf("%s %s", record.IP, record.Domain)
+		}
+
+		return hosts, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseHosts(hosts), nil
+}
+
+// Remove deletes the passed DNS records, batched with concurrent writes of DNS records
+func (dns localDNSAPI) Remove(ctx context.Context, records pihole.DNSRecordList) error {
+	_, err := dns.client.UpdateConfigArray(ctx, hostsPath, func(hosts []string) ([]string, error) {
+		for _, record := range records {
+			hosts = removeHost(hosts, record.Domain, record.IP)
+		}
+
+		return hosts, nil
+	})
+
+	return err
+}
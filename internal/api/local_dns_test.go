@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	pihole "github.com/ryanwholey/go-pihole"
+)
+
+func TestReplaceHostIP(t *testing.T) {
+	cases := []struct {
+		hosts    []string
+		domain   string
+		ip       string
+		newIP    string
+		expected []string
+	}{
+		{
+			hosts:    []string{"127.0.0.1 foo.com", "127.0.0.1 bar.com"},
+			domain:   "foo.com",
+			ip:       "127.0.0.1",
+			newIP:    "127.0.0.2",
+			expected: []string{"127.0.0.2 foo.com", "127.0.0.1 bar.com"},
+		},
+		{
+			hosts:    []string{"127.0.0.1 foo.com", "fd00::1 foo.com"},
+			domain:   "foo.com",
+			ip:       "fd00:0::1",
+			newIP:    "fd00::2",
+			expected: []string{"127.0.0.1 foo.com", "fd00::2 foo.com"},
+		},
+		{
+			hosts:    []string{"127.0.0.1 bar.com foo.com baz.com"},
+			domain:   "foo.com",
+			ip:       "127.0.0.1",
+			newIP:    "127.0.0.2",
+			expected: []string{"127.0.0.1 bar.com baz.com", "127.0.0.2 foo.com"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := replaceHostIP(c.hosts, c.domain, c.ip, c.newIP)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, actual)
+		}
+	}
+
+	if _, err := replaceHostIP([]string{"127.0.0.1 foo.com"}, "foo.com", "127.0.0.2", "127.0.0.3"); !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestRemoveHost(t *testing.T) {
+	cases := []struct {
+		hosts    []string
+		domain   string
+		ip       string
+		expected []string
+	}{
+		{
+			hosts:    []string{"127.0.0.1 foo.com", "127.0.0.1 bar.com"},
+			domain:   "foo.com",
+			ip:       "127.0.0.1",
+			expected: []string{"127.0.0.1 bar.com"},
+		},
+		{
+			hosts:    []string{"127.0.0.1 foo.com", "fd00::1 foo.com"},
+			domain:   "FOO.com",
+			ip:       "fd00:0::1",
+			expected: []string{"127.0.0.1 foo.com"},
+		},
+		{
+			hosts:    []string{"127.0.0.1 foo.com bar.com baz.com"},
+			domain:   "bar.com",
+			ip:       "127.0.0.1",
+			expected: []string{"127.0.0.1 foo.com baz.com"},
+		},
+		{
+			hosts:    []string{"127.0.0.1 foo.com"},
+			domain:   "foo.com",
+			ip:       "127.0.0.2",
+			expected: []string{"127.0.0.1 foo.com"},
+		},
+	}
+
+	for _, c := range cases {
+		actual := removeHost(c.hosts, c.domain, c.ip)
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, actual)
+		}
+	}
+}
+
+func TestLocalDNSRemove(t *testing.T) {
+	server := &testConfigServer{hosts: []string{"127.0.0.1 foo.com bar.com", "127.0.0.2 baz.com"}}
+	dns := NewLocalDNSAPI(newTestClient(t, server))
+
+	if err := dns.Remove(context.Background(), pihole.DNSRecordList{{Domain: "foo.com", IP: "127.0.0.1"}, {Domain: "baz.com", IP: "127.0.0.2"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(server.hosts) != 1 || server.hosts[0] != "127.0.0.1 bar.com" {
+		t.Fatalf("expected records which are not removed to be kept, got %v", server.hosts)
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type ResolverAPI interface {
+	// Process returns the running FTL process.
+	Process(ctx context.Context) (*FTLProcess, error)
+
+	// Restart restarts the DNS resolver of FTL.
+	Restart(ctx context.Context) error
+
+	// Healthy reports whether FTL restarted since the passed process was running and its DNS resolver is up.
+	Healthy(ctx context.Context, before *FTLProcess) (bool, error)
+}
+
+type resolverAPI struct {
+	client *Client
+}
+
+// NewResolverAPI returns the DNS resolver API for the passed client
+func NewResolverAPI(client *Client) ResolverAPI {
+	return &resolverAPI{client: client}
+}
+
+// FTLProcess identifies a running FTL process, FTL restarts asynchronously so a restart is detected by a
+// changed process ID or a reset uptime
+type FTLProcess struct {
+	PID    int   `json:"pid"`
+	Uptime int64 `json:"uptime"`
+}
+
+// replaced reports whether the process was restarted since the passed process was running
+func (p FTLProcess) replaced(before FTLProcess) bool {
+	return p.PID != before.PID || p.Uptime < before.Uptime
+}
+
+type ftlResponse struct {
+	FTL FTLProcess `json:"ftl"`
+}
+
+type actionResponse struct {
+	Status string `json:"status"`
+}
+
+// Process returns the ID and uptime of the running FTL process
+func (r resolverAPI) Process(ctx context.Context) (*FTLProcess, error) {
+	res, err := r.client.Get(ctx, "/api/info/ftl")
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var ftlRes ftlResponse
+	if err := json.NewDecoder(res.Body).Decode(&ftlRes); err != nil {
+		return nil, fmt.Errorf("failed to parse FTL info body: %w", err)
+	}
+
+	return &ftlRes.FTL, nil
+}
+
+// Restart restarts the DNS resolver, which flushes the DNS cache and applies configuration requiring a restart
+func (r resolverAPI) Restart(ctx context.Context) error {
+	res, err := r.client.do(ctx, http.MethodPost, "/api/action/restartdns", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return unexpectedStatus(res)
+	}
+
+	var actionRes actionResponse
+	if err := json.NewDecoder(res.Body).Decode(&actionRes); err != nil {
+		return fmt.Errorf("failed to parse restart response body: %w", err)
+	}
+
+	if actionRes.Status != "success" {
+		return fmt.Errorf("failed to restart DNS resolver: status %q", actionRes.Status)
+	}
+
+	return nil
+}
+
+// Healthy reports whether FTL replaced the passed process and the resolver reports a known blocking status,
+// which is unknown while the resolver restarts. Requests failing while FTL is down are returned as errors.
+func (r resolverAPI) Healthy(ctx context.Context, before *FTLProcess) (bool, error) {
+	process, err := r.Process(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !process.replaced(*before) {
+		return false, nil
+	}
+
+	blocking, err := NewBlockingAPI(r.client).Get(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return blocking.Status == BlockingEnabled || blocking.Status == BlockingDisabled, nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// testResolverServer restarts FTL after a number of requests following a restart request, reporting an unknown
+// blocking status for the first requests after the restart
+type testResolverServer struct {
+	mu       sync.Mutex
+	pid      int
+	uptime   int64
+	restarts int
+	delay    int
+	pending  int
+}
+
+func (s *testResolverServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.uptime += 1000
+
+	if s.delay > 0 {
+		if s.delay--; s.delay == 0 {
+			s.pid++
+			s.uptime = 0
+			s.pending = 1
+		}
+	}
+
+	switch r.URL.Path {
+	case "/api/action/restartdns":
+		s.restarts++
+		s.delay = 2
+		_, _ = fmt.Fprint(w, `{"status":"success"}`)
+	case "/api/info/ftl":
+		_, _ = fmt.Fprintf(w, `{"ftl":{"pid":%d,"uptime":%d}}`, s.pid, s.uptime)
+	case "/api/dns/blocking":
+		status := "enabled"
+		if s.pending > 0 {
+			s.pending--
+			status = "unknown"
+		}
+
+		_, _ = fmt.Fprintf(w, `{"blocking":%q,"timer":null}`, status)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestResolverRestart(t *testing.T) {
+	server := &testResolverServer{pid: 100, uptime: 60000}
+	resolver := NewResolverAPI(newTestClient(t, server))
+
+	before, err := resolver.Process(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := resolver.Restart(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The previous process is reported until FTL restarted, then the blocking status is unknown once
+	for _, expected := range []bool{false, false, true} {
+		healthy, err := resolver.Healthy(context.Background(), before)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if healthy != expected {
+			t.Fatalf("expected healthy %t, got %t", expected, healthy)
+		}
+	}
+
+	if server.restarts != 1 || server.pid != 101 {
+		t.Fatalf("expected 1 restart, got %d with pid %d", server.restarts, server.pid)
+	}
+}
+
+func TestFTLProcessReplaced(t *testing.T) {
+	before := FTLProcess{PID: 100, Uptime: 60000}
+
+	cases := []struct {
+		process  FTLProcess
+		expected bool
+	}{
+		{process: FTLProcess{PID: 100, Uptime: 61000}, expected: false},
+		{process: FTLProcess{PID: 101, Uptime: 61000}, expected: true},
+		{process: FTLProcess{PID: 100, Uptime: 500}, expected: true},
+	}
+
+	for _, c := range cases {
+		if actual := c.process.replaced(before); actual != c.expected {
+			t.Errorf("expected replaced %t for %+v, got %t", c.expected, c.process, actual)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrorReverseServerNotFound is returned when no reverse server is configured for a subnet
+	ErrorReverseServerNotFound = errors.New("reverse server not found")
+)
+
+type ReverseServersAPI interface {
+	// List all reverse servers.
+	List(ctx context.Context) ([]ReverseServer, error)
+
+	// Get the reverse server of a subnet.
+	Get(ctx context.Context, cidr string) (*ReverseServer, error)
+
+	// Create a reverse server for a subnet.
+	Create(ctx context.Context, server ReverseServer) (*ReverseServer, error)
+
+	// Update the reverse server of a subnet in a single write.
+	Update(ctx context.Context, server ReverseServer) (*ReverseServer, error)
+
+	// Delete the reverse server of a subnet.
+	Delete(ctx context.Context, cidr string) error
+}
+
+type reverseServersAPI struct {
+	client *Client
+}
+
+// NewReverseServersAPI returns the conditional forwarding API for the passed client
+func NewReverseServersAPI(client *Client) ReverseServersAPI {
+	return &reverseServersAPI{client: client}
+}
+
+// ReverseServer forwards reverse lookups of a subnet, and lookups of its local domain, to a DNS server such as a router
+type ReverseServer struct {
+	Enabled bool
+	CIDR    string
+	Server  string
+	Domain  string
+}
+
+// revServersPath is the config path of the reverse servers
+const revServersPath = "dns/revServers"
+
+// ValidateCIDR validates a subnet in CIDR notation without host bits, e.g. 192.168.1.0/24
+func ValidateCIDR(cidr string) error {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Errorf("invalid CIDR %q, expected a subnet such as 192.168.1.0/24", cidr)
+	}
+
+	if !ip.Equal(network.IP) {
+		return fmt.Errorf("invalid CIDR %q, host bits are set, expected %s", cidr, network)
+	}
+
+	return nil
+}
+
+// ValidateReverseServer validates the subnet, server and local domain of a reverse server
+func ValidateReverseServer(server ReverseServer) error {
+	if err := ValidateCIDR(server.CIDR); err != nil {
+		return err
+	}
+
+	if err := ValidateServer(server.Server); err != nil {
+		return fmt.Errorf("invalid server %q: %w", server.Server, err)
+	}
+
+	if strings.ContainsAny(server.Domain, ", ") {
+		return fmt.Errorf("invalid domain %q", server.Domain)
+	}
+
+	return nil
+}
+
+// SameCIDR reports whether two subnets are equal, ignoring differences in notation
+func SameCIDR(a string, b string) bool {
+	_, networkA, errA := net.ParseCIDR(a)
+	_, networkB, errB := net.ParseCIDR(b)
+	if errA == nil && errB == nil {
+		return networkA.IP.Equal(networkB.IP) && networkA.Mask.String() == networkB.Mask.String()
+	}
+
+	return strings.EqualFold(a, b)
+}
+
+// reverseServerEntry returns the "enabled,cidr,server,domain" dns.revServers entry of a reverse server
+func reverseServerEntry(server ReverseServer) string {
+	return fmt.Sprintf("%t,%s,%s,%s", server.Enabled, server.CIDR, server.Server, server.Domain)
+}
+
+// parseReverseServers returns the reverse servers of a list of "enabled,cidr,server[,domain]" entries
+func parseReverseServers(entries []string) []ReverseServer {
+	list := make([]ReverseServer, 0, len(entries))
+
+	for _, entry := range entries {
+		fields := strings.Split(entry, ",")
+		if len(fields) < 3 {
+			continue
+		}
+
+		enabled, _ := strconv.ParseBool(fields[0])
+
+		server := ReverseServer{
+			Enabled: enabled,
+			CIDR:    fields[1],
+			Server:  fields[2],
+		}
+
+		if len(fields) > 3 {
+			server.Domain = fields[3]
+		}
+
+		list = append(list, server)
+	}
+
+	return list
+}
+
+// findReverseServer returns the reverse server of the subnet
+func findReverseServer(servers []ReverseServer, cidr string) (*ReverseServer, error) {
+	for _, server := range servers {
+		if SameCIDR(server.CIDR, cidr) {
+			return &server, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorReverseServerNotFound, cidr)
+}
+
+// List returns all reverse servers
+func (r reverseServersAPI) List(ctx context.Context) ([]ReverseServer, error) {
+	entries, err := r.client.ReadConfigArray(ctx, revServersPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReverseServers(entries), nil
+}
+
+// Get returns the reverse server of a subnet
+func (r reverseServersAPI) Get(ctx context.Context, cidr string) (*ReverseServer, error) {
+	servers, err := r.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reverse servers: %w", err)
+	}
+
+	return findReverseServer(servers, cidr)
+}
+
+// Create creates a reverse server, batched with concurrent writes of reverse servers
+func (r reverseServersAPI) Create(ctx context.Context, server ReverseServer) (*ReverseServer, error) {
+	if err := ValidateReverseServer(server); err != nil {
+		return nil, err
+	}
+
+	entries, err := r.client.UpdateConfigArray(ctx, revServersPath, func(entries []string) ([]string, error) {
+		if _, err := findReverseServer(parseReverseServers(entries), server.CIDR); err == nil {
+			return nil, fmt.Errorf("reverse server for %s already exists", server.CIDR)
+		}
+
+		return append(entries, reverseServerEntry(server)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findReverseServer(parseReverseServers(entries), server.CIDR)
+}
+
+// Update replaces the reverse server of the subnet within the reverse server list,
+// batched with concurrent writes of reverse servers
+func (r reverseServersAPI) Update(ctx context.Context, server ReverseServer) (*ReverseServer, error) {
+	if err := ValidateReverseServer(server); err != nil {
+		return nil, err
+	}
+
+	entries, err := r.client.UpdateConfigArray(ctx, revServersPath, func(entries []string) ([]string, error) {
+		return replaceReverseServer(entries, server)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findReverseServer(parseReverseServers(entries), server.CIDR)
+}
+
+// replaceReverseServer replaces the entry of a subnet within a list of "enabled,cidr,server[,domain]" entries
+func replaceReverseServer(entries []string, server ReverseServer) ([]string, error) {
+	for i, entry := range entries {
+		fields := strings.Split(entry, ",")
+		if len(fields) < 3 || !SameCIDR(fields[1], server.CIDR) {
+			continue
+		}
+
+		server.CIDR = fields[1]
+		entries[i] = reverseServerEntry(server)
+
+		return entries, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorReverseServerNotFound, server.CIDR)
+}
+
+// Delete removes the reverse server of the subnet, batched with concurrent writes of reverse servers
+func (r reverseServersAPI) Delete(ctx context.Context, cidr string) error {
+	_, err := r.client.UpdateConfigArray(ctx, revServersPath, func(entries []string) ([]string, error) {
+		updated := make([]string, 0, len(entries))
+
+		for _, entry := range entries {
+			if fields := strings.Split(entry, ","); len(fields) < 3 || !SameCIDR(fields[1], cidr) {
+				updated = append(updated, entry)
+			}
+		}
+
+		return updated, nil
+	})
+
+	return err
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidateReverseServer(t *testing.T) {
+	valid := []ReverseServer{
+		{CIDR: "192.168.1.0/24", Server: "192.168.1.1", Domain: "lan"},
+		{CIDR: "10.20.0.0/16", Server: "10.20.0.1#5353", Domain: "iot.home.arpa"},
+		{CIDR: "fd00:1::/64", Server: "fd00:1::1"},
+	}
+	for _, server := range valid {
+		if err := ValidateReverseServer(server); err != nil {
+			t.Errorf("expected %v to be valid: %s", server, err)
+		}
+	}
+
+	invalid := []ReverseServer{
+		{CIDR: "192.168.1.0", Server: "192.168.1.1"},
+		{CIDR: "192.168.1.1/24", Server: "192.168.1.1"},
+		{CIDR: "192.168.1.0/33", Server: "192.168.1.1"},
+		{CIDR: "192.168.1.0/24", Server: "router.lan"},
+		{CIDR: "192.168.1.0/24", Server: "192.168.1.1#0"},
+		{CIDR: "192.168.1.0/24", Server: "192.168.1.1", Domain: "lan,iot"},
+	}
+	for _, server := range invalid {
+		if err := ValidateReverseServer(server); err == nil {
+			t.Errorf("expected %v to be invalid", server)
+		}
+	}
+}
+
+func TestParseReverseServers(t *testing.T) {
+	actual := parseReverseServers([]string{
+		"true,192.168.1.0/24,192.168.1.1,lan",
+		"false,10.0.0.0/8,10.0.0.1#53",
+		"invalid",
+	})
+
+	expected := []ReverseServer{
+		{Enabled: true, CIDR: "192.168.1.0/24", Server: "192.168.1.1", Domain: "lan"},
+		{Enabled: false, CIDR: "10.0.0.0/8", Server: "10.0.0.1#53"},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestReplaceReverseServer(t *testing.T) {
+	entries := []string{"true,10.0.0.0/8,10.0.0.1,", "true,fd00:1::/64,fd00:1::1,lan"}
+
+	actual, err := replaceReverseServer(entries, ReverseServer{CIDR: "FD00:1:0::/64", Server: "fd00:1::53", Domain: "home.arpa"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{"true,10.0.0.0/8,10.0.0.1,", "false,fd00:1::/64,fd00:1::53,home.arpa"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+
+	if _, err := replaceReverseServer(entries, ReverseServer{CIDR: "192.168.1.0/24"}); !errors.Is(err, ErrorReverseServerNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
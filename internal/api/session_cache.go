@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SessionCache persists session IDs by Pi-hole URL in a JSON file, so sessions can be reused across provider runs
+type SessionCache struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewSessionCache returns a session cache backed by the file at path, which is created on the first write
+func NewSessionCache(path string) *SessionCache {
+	return &SessionCache{path: path}
+}
+
+// read returns the cached session IDs by URL, which are empty when the cache file does not exist yet
+func (c *SessionCache) read() (map[string]string, error) {
+	sessions := map[string]string{}
+
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return sessions, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse session cache %s: %w", c.path, err)
+	}
+
+	return sessions, nil
+}
+
+// Get returns the cached session ID of the URL, or an empty string
+func (c *SessionCache) Get(url string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sessions, err := c.read()
+	if err != nil {
+		return "", err
+	}
+
+	return sessions[url], nil
+}
+
+// Set caches the session ID of the URL. The file is replaced atomically so concurrent provider processes never read a partial file.
+func (c *SessionCache) Set(url string, sid string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sessions, err := c.read()
+	if err != nil {
+		sessions = map[string]string{}
+	}
+
+	sessions[url] = sid
+
+	b, err := json.Marshal(sessions)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type TeleporterAPI interface {
+	// Backup downloads a Teleporter backup archive.
+	Backup(ctx context.Context) (*TeleporterArchive, error)
+
+	// Restore imports a Teleporter backup archive, restricted to the passed sections when any are passed.
+	Restore(ctx context.Context, archive []byte, sections []string) ([]string, error)
+}
+
+// TeleporterSections are the sections of a Teleporter archive which can be imported. Gravity sections
+// are the tables of the gravity database.
+var TeleporterSections = []string{
+	"config",
+	"dhcp_leases",
+	"gravity.group",
+	"gravity.adlist",
+	"gravity.adlist_by_group",
+	"gravity.domainlist",
+	"gravity.domainlist_by_group",
+	"gravity.client",
+	"gravity.client_by_group",
+}
+
+type teleporterAPI struct {
+	client *Client
+}
+
+// NewTeleporterAPI returns the Teleporter backup API for the passed client
+func NewTeleporterAPI(client *Client) TeleporterAPI {
+	return &teleporterAPI{client: client}
+}
+
+// TeleporterArchive is a Teleporter backup archive in zip format
+type TeleporterArchive struct {
+	Filename string
+	Content  []byte
+}
+
+type teleporterResponse struct {
+	Files []string `json:"files"`
+}
+
+// teleporterImport returns the import field selecting the passed sections, all sections are imported without it
+func teleporterImport(sections []string) (string, error) {
+	selected := map[string]bool{}
+	for _, section := range sections {
+		if !slices.Contains(TeleporterSections, section) {
+			return "", fmt.Errorf("unknown Teleporter section %q", section)
+		}
+
+		selected[section] = true
+	}
+
+	gravity := map[string]bool{}
+	selection := map[string]interface{}{"gravity": gravity}
+
+	for _, section := range TeleporterSections {
+		if table, ok := strings.CutPrefix(section, "gravity."); ok {
+			gravity[table] = selected[section]
+		} else {
+			selection[section] = selected[section]
+		}
+	}
+
+	b, err := json.Marshal(selection)
+
+	return string(b), err
+}
+
+// Backup downloads a Teleporter backup archive of the Pi-hole configuration and gravity database
+func (t teleporterAPI) Backup(ctx context.Context) (*TeleporterArchive, error) {
+	res, err := t.client.do(ctx, http.MethodGet, "/api/teleporter", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download Teleporter archive: %w", err)
+	}
+
+	archive := &TeleporterArchive{Content: content}
+
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil {
+		archive.Filename = params["filename"]
+	}
+
+	return archive, nil
+}
+
+// Restore imports a Teleporter backup archive and returns the imported files
+func (t teleporterAPI) Restore(ctx context.Context, archive []byte, sections []string) ([]string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	file, err := writer.CreateFormFile("file", "pi-hole-teleporter.zip")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Write(archive); err != nil {
+		return nil, err
+	}
+
+	if len(sections) > 0 {
+		selection, err := teleporterImport(sections)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writer.WriteField("import", selection); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	res, err := t.client.send(ctx, http.MethodPost, "/api/teleporter", writer.FormDataContentType(), &body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, unexpectedStatus(res)
+	}
+
+	var teleporterRes teleporterResponse
+	if err := json.NewDecoder(res.Body).Decode(&teleporterRes); err != nil {
+		return nil, fmt.Errorf("failed to parse Teleporter response body: %w", err)
+	}
+
+	return teleporterRes.Files, nil
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestTeleporterRestore(t *testing.T) {
+	var archive []byte
+	var selection map[string]interface{}
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if archive, err = io.ReadAll(file); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := json.Unmarshal([]byte(r.FormValue("import")), &selection); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, `{"files":["etc/pihole/pihole.toml","etc/pihole/gravity.db->group"]}`)
+	}))
+
+	files, err := NewTeleporterAPI(client).Restore(context.Background(), []byte("zip"), []string{"config", "gravity.group"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 || !bytes.Equal(archive, []byte("zip")) {
+		t.Fatalf("unexpected restore of %q: %v", archive, files)
+	}
+
+	gravity := selection["gravity"].(map[string]interface{})
+	if selection["config"] != true || selection["dhcp_leases"] != false || gravity["group"] != true || gravity["adlist"] != false {
+		t.Fatalf("unexpected import selection %v", selection)
+	}
+
+	if _, err := NewTeleporterAPI(client).Restore(context.Background(), []byte("zip"), []string{"gravity.unknown"}); err == nil {
+		t.Fatal("expected unknown section error")
+	}
+}
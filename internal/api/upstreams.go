@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+type UpstreamsAPI interface {
+	// Get the upstream DNS servers in the order they are configured.
+	Get(ctx context.Context) ([]string, error)
+
+	// Set replaces the upstream DNS servers in a single write.
+	Set(ctx context.Context, upstreams []string) ([]string, error)
+}
+
+type upstreamsAPI struct {
+	client *Client
+}
+
+// NewUpstreamsAPI returns the upstream DNS servers API for the passed client
+func NewUpstreamsAPI(client *Client) UpstreamsAPI {
+	return &upstreamsAPI{client: client}
+}
+
+// upstreamsPath is the config path of the upstream DNS servers
+const upstreamsPath = "dns/upstreams"
+
+// ValidateUpstream validates an upstream DNS server in ip or ip#port format, e.g. 1.1.1.1#53 or ::1#5335
+func ValidateUpstream(upstream string) error {
+	if err := ValidateServer(upstream); err != nil {
+		return fmt.Errorf("invalid upstream %q: %w", upstream, err)
+	}
+
+	return nil
+}
+
+// ValidateServer validates a DNS server address in ip or ip#port format
+func ValidateServer(server string) error {
+	ip, port, hasPort := strings.Cut(server, "#")
+
+	if net.ParseIP(ip) == nil {
+		return errors.New("expected an IP address optionally followed by #port")
+	}
+
+	if hasPort {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return errors.New("expected a port between 1 and 65535")
+		}
+	}
+
+	return nil
+}
+
+// Get returns the upstream DNS servers
+func (u upstreamsAPI) Get(ctx context.Context) ([]string, error) {
+	return u.client.ReadConfigArray(ctx, upstreamsPath)
+}
+
+// Set replaces the upstream DNS servers, batched with concurrent writes of the upstream DNS servers
+func (u upstreamsAPI) Set(ctx context.Context, upstreams []string) ([]string, error) {
+	for _, upstream := range upstreams {
+		if err := ValidateUpstream(upstream); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.client.UpdateConfigArray(ctx, upstreamsPath, func([]string) ([]string, error) {
+		return upstreams, nil
+	})
+}
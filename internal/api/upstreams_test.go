@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestValidateUpstream(t *testing.T) {
+	valid := []string{"1.1.1.1", "1.1.1.1#53", "127.0.0.1#5335", "2001:db8::1", "::1#5353"}
+	for _, upstream := range valid {
+		if err := ValidateUpstream(upstream); err != nil {
+			t.Errorf("expected %q to be valid: %s", upstream, err)
+		}
+	}
+
+	invalid := []string{"", "dns.google", "1.1.1.1:53", "1.1.1.1#", "1.1.1.1#0", "1.1.1.1#65536", "[::1]#53"}
+	for _, upstream := range invalid {
+		if err := ValidateUpstream(upstream); err == nil {
+			t.Errorf("expected %q to be invalid", upstream)
+		}
+	}
+}
@@ -5,11 +5,16 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
 	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
 // Config defines the configuration options for the Pi-hole client
@@ -20,46 +25,202 @@ type Config struct {
 	// The Pi-hole admin password
 	Password string
 
+	// The Pi-hole application password, used instead of the admin password
+	APIToken string
+
+	// Base32 encoded secret to generate two-factor authentication codes
+	TOTPSecret string
+
 	// UserAgent for requests
 	UserAgent string
 
 	// Custom CA file
 	CAFile string
 
+	// Custom CA PEM content
+	CAPEM string
+
+	// Skip the verification of the server's certificate chain and host name
+	InsecureSkipVerify bool
+
+	// Client certificate and key, as PEM content or file paths, for mutual TLS
+	ClientCert string
+	ClientKey  string
+
+	// Server name used to verify the server's certificate, instead of the URL's host
+	TLSServerName string
+
+	// Maximum number of retries of failed requests
+	MaxRetries int
+
+	// Minimum and maximum wait between retries, unless the server sets a Retry-After header
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	// Timeout of each request attempt, 0 for no timeout
+	RequestTimeout time.Duration
+
+	// Maximum number of concurrent requests, 0 for no limit
+	MaxConcurrentRequests int
+
+	// HTTP, HTTPS or SOCKS5 proxy to connect through, instead of the proxy environment variables
+	ProxyURL string
+
+	// Unix socket to connect through, the URL is still used for the Host header and paths
+	UnixSocket string
+
 	// SessionID can be passed to reduce the number of requests against the /api/auth endpoint
 	SessionID string
+
+	// SessionCacheFile caches sessions by URL to reuse them across provider runs
+	SessionCacheFile string
+}
+
+// pemContent returns PEM content passed inline or read from a file path
+func pemContent(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
 }
 
-func (c Config) Client(ctx context.Context) (*pihole.Client, error) {
-	httpClient := retryablehttp.NewClient().StandardClient()
+// tlsConfig returns the TLS configuration of the client, or nil to use the transport defaults
+func (c Config) tlsConfig() (*tls.Config, error) {
+	if c.CAFile == "" && c.CAPEM == "" && !c.InsecureSkipVerify && c.ClientCert == "" && c.ClientKey == "" && c.TLSServerName == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		ServerName:         c.TLSServerName,
+	}
+
+	if c.CAFile != "" || c.CAPEM != "" {
+		ca := []byte(c.CAPEM)
+
+		if c.CAFile != "" {
+			var err error
+			if ca, err = os.ReadFile(c.CAFile); err != nil {
+				return nil, fmt.Errorf("failed to read CA file %q: %w", c.CAFile, err)
+			}
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no CA certificates found")
+		}
+	}
 
-	if c.CAFile != "" {
-		ca, err := os.ReadFile(c.CAFile)
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := pemContent(c.ClientCert)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read CA file %q: %w", c.CAFile, err)
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
 		}
 
-		rootCAs := x509.NewCertPool()
-		rootCAs.AppendCertsFromPEM(ca)
+		key, err := pemContent(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
 
-		transport := &http.Transport{}
-		transport.TLSClientConfig = &tls.Config{
-			RootCAs: rootCAs,
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
 		}
 
-		httpClient.Transport = transport
+		tlsConfig.Certificates = []tls.Certificate{pair}
 	}
 
+	return tlsConfig, nil
+}
+
+// configureConnection routes the transport's connections through the configured proxy or Unix socket
+func (c Config) configureConnection(transport *http.Transport) error {
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("unsupported proxy URL scheme %q, expected one of http, https or socks5", proxyURL.Scheme)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.UnixSocket != "" {
+		dialer := &net.Dialer{}
+
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _ string, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", c.UnixSocket)
+		}
+	}
+
+	return nil
+}
+
+func (c Config) Client(ctx context.Context) (*api.Client, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = c.MaxRetries
+	retryClient.HTTPClient.Timeout = c.RequestTimeout
+
+	if c.RetryWaitMin > 0 {
+		retryClient.RetryWaitMin = c.RetryWaitMin
+	}
+
+	if c.RetryWaitMax > 0 {
+		retryClient.RetryWaitMax = c.RetryWaitMax
+	}
+
+	// TLS and connection options are set on the retryable client's pooled transport to keep its timeout defaults
+	if transport, ok := retryClient.HTTPClient.Transport.(*http.Transport); ok {
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+		}
+
+		if err := c.configureConnection(transport); err != nil {
+			return nil, err
+		}
+	}
+
+	// The limiter applies to each attempt, so requests waiting to be retried do not hold a slot
+	if c.MaxConcurrentRequests > 0 {
+		retryClient.HTTPClient.Transport = api.NewLimitTransport(retryClient.HTTPClient.Transport, c.MaxConcurrentRequests)
+	}
+
+	httpClient := retryClient.StandardClient()
+
 	headers := http.Header{}
 	headers.Add("User-Agent", c.UserAgent)
 
-	config := pihole.Config{
-		BaseURL:    c.URL,
-		Password:   c.Password,
-		Headers:    headers,
-		HttpClient: httpClient,
-		SessionID:  c.SessionID,
+	password := c.Password
+	if c.APIToken != "" {
+		password = c.APIToken
+	}
+
+	config := api.Config{
+		Config: pihole.Config{
+			BaseURL:    c.URL,
+			Password:   password,
+			Headers:    headers,
+			HttpClient: httpClient,
+			SessionID:  c.SessionID,
+		},
+		TOTPSecret: c.TOTPSecret,
+	}
+
+	if c.SessionCacheFile != "" {
+		config.SessionCache = api.NewSessionCache(c.SessionCacheFile)
 	}
 
-	return pihole.New(config)
+	return api.New(config)
 }
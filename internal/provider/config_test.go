@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed PEM encoded certificate and key
+func testCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pi.hole"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(cert), string(keyPEM)
+}
+
+func TestConfigTLS(t *testing.T) {
+	cert, key := testCertificate(t)
+
+	keyFile := filepath.Join(t.TempDir(), "client.key")
+	if err := os.WriteFile(keyFile, []byte(key), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if tlsConfig, err := (Config{}).tlsConfig(); err != nil || tlsConfig != nil {
+		t.Fatalf("expected default TLS configuration, got %v, %v", tlsConfig, err)
+	}
+
+	tlsConfig, err := Config{
+		CAPEM:         cert,
+		ClientCert:    cert,
+		ClientKey:     keyFile,
+		TLSServerName: "pi.hole",
+	}.tlsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tlsConfig.RootCAs == nil || len(tlsConfig.Certificates) != 1 || tlsConfig.ServerName != "pi.hole" || tlsConfig.InsecureSkipVerify {
+		t.Fatalf("unexpected TLS configuration %+v", tlsConfig)
+	}
+
+	if tlsConfig, err = (Config{InsecureSkipVerify: true}).tlsConfig(); err != nil || !tlsConfig.InsecureSkipVerify {
+		t.Fatalf("expected insecure TLS configuration, got %v, %v", tlsConfig, err)
+	}
+
+	if _, err := (Config{CAPEM: "invalid"}).tlsConfig(); err == nil {
+		t.Fatal("expected invalid CA error")
+	}
+
+	if _, err := (Config{ClientCert: cert, ClientKey: cert}).tlsConfig(); err == nil {
+		t.Fatal("expected invalid client key error")
+	}
+}
+
+func TestConfigUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "pihole.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "pi.hole" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"config":{"dns":{"hosts":["127.0.0.1 foo.com"]}}}`))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	client, err := Config{
+		URL:        "http://pi.hole",
+		SessionID:  "test",
+		UnixSocket: socket,
+	}.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hosts, err := client.GetConfigArray(context.Background(), "dns/hosts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hosts) != 1 {
+		t.Fatalf("unexpected hosts %v", hosts)
+	}
+}
+
+func TestConfigProxyURL(t *testing.T) {
+	if _, err := (Config{URL: "http://pi.hole", ProxyURL: "ftp://proxy"}).Client(context.Background()); err == nil {
+		t.Fatal("expected unsupported proxy scheme error")
+	}
+
+	if _, err := (Config{URL: "http://pi.hole", ProxyURL: "socks5://localhost:1080"}).Client(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
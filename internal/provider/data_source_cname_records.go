@@ -7,7 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
 // dataSourceCNAMERecords returns a schema resource for listing Pi-hole CNAME records
@@ -31,6 +31,11 @@ func dataSourceCNAMERecords() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"ttl": {
+							Description: "TTL in seconds of the CNAME record, 0 when unset",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -40,12 +45,12 @@ func dataSourceCNAMERecords() *schema.Resource {
 
 // dataSourceCNAMERecordsRead lists all Pi-hole CNAME records
 func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := metaClient(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	cnameList, err := client.LocalCNAME.List(ctx)
+	cnameList, err := api.NewLocalCNAMEAPI(client).List(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -54,11 +59,12 @@ func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, met
 	idRef := ""
 
 	for i, r := range cnameList {
-		idRef = fmt.Sprintf("%s%s%s", idRef, r.Domain, r.Target)
+		idRef = fmt.Sprintf("%s%s%s%d", idRef, r.Domain, r.Target, r.TTL)
 
 		list[i] = map[string]interface{}{
 			"domain": r.Domain,
 			"target": r.Target,
+			"ttl":    r.TTL,
 		}
 	}
 
@@ -16,6 +16,7 @@ func TestAccCNAMERecordsData(t *testing.T) {
 					resource "pihole_cname_record" "record" {
 					  domain = "foo.com"
 					  target = "bar.com"
+					  ttl    = 300
 					}
 
 					data "pihole_cname_records" "records" {
@@ -27,6 +28,7 @@ func TestAccCNAMERecordsData(t *testing.T) {
 
 					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.domain", "foo.com"),
 					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.target", "bar.com"),
+					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.ttl", "300"),
 				),
 			},
 		},
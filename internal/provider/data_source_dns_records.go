@@ -7,7 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
 // dataSourceDNSRecords returns a schema resource for listing Pi-hole local DNS records
@@ -40,12 +40,12 @@ func dataSourceDNSRecords() *schema.Resource {
 
 // dataSourceDNSRecordsRead lists all Pi-hole local DNS records
 func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+	client, ok := metaClient(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	dnsList, err := client.LocalDNS.List(ctx)
+	dnsList, err := api.NewLocalDNSAPI(client).List(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
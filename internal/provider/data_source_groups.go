@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// dataSourceGroups returns a schema resource for listing Pi-hole groups
+func dataSourceGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists Pi-hole groups",
+		ReadContext: dataSourceGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"groups": {
+				Description: "List of Pi-hole groups",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Group ID",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Group name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "Group description",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"enabled": {
+							Description: "Whether the group is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceGroupsRead lists all Pi-hole groups
+func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	groupList, err := api.NewGroupAPI(client).List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list := make([]map[string]interface{}, len(groupList))
+	idRef := ""
+
+	for i, g := range groupList {
+		idRef = fmt.Sprintf("%s%d%s", idRef, g.ID, g.Name)
+
+		list[i] = map[string]interface{}{
+			"id":          int(g.ID),
+			"name":        g.Name,
+			"description": g.Comment,
+			"enabled":     g.Enabled,
+		}
+	}
+
+	if err := d.Set("groups", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash := sha256.Sum256([]byte(idRef))
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+
+	return diags
+}
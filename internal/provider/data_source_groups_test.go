@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGroupsData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_group" "group" {
+					  name        = "guests"
+					  description = "Guest devices"
+					}
+
+					data "pihole_groups" "groups" {
+					  depends_on = [pihole_group.group]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_groups.groups", "groups.*", map[string]string{
+						"id":      "0",
+						"enabled": "true",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_groups.groups", "groups.*", map[string]string{
+						"name":        "guests",
+						"description": "Guest devices",
+						"enabled":     "true",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// dataSourceTeleporterBackup returns a schema resource for downloading a Teleporter backup archive
+func dataSourceTeleporterBackup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Downloads a Pi-hole Teleporter backup archive of the configuration and gravity database. A new archive is " +
+			"downloaded on each read, i.e. also during plans. The archive contains secrets such as the password hash",
+		ReadContext: dataSourceTeleporterBackupRead,
+		Schema: map[string]*schema.Schema{
+			"output_path": {
+				Description: "Path to write the zip archive to. The archive is returned as content_base64 instead when unset",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"content_base64": {
+				Description: "Base64 encoded zip archive, empty when output_path is set",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"filename": {
+				Description: "File name of the archive suggested by Pi-hole, including the backup time",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"sha256": {
+				Description: "SHA256 checksum of the archive",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceTeleporterBackupRead downloads a Teleporter backup archive
+func dataSourceTeleporterBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	archive, err := api.NewTeleporterAPI(client).Backup(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	content := base64.StdEncoding.EncodeToString(archive.Content)
+
+	if path := d.Get("output_path").(string); path != "" {
+		if err := os.WriteFile(path, archive.Content, 0o600); err != nil {
+			return diag.FromErr(fmt.Errorf("failed to write Teleporter archive: %w", err))
+		}
+
+		content = ""
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256(archive.Content))
+
+	if err := d.Set("content_base64", content); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("filename", archive.Filename); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("sha256", hash); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(hash)
+
+	return diags
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTeleporterBackupData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teleporter.zip")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "pihole_teleporter_backup" "base64" {}
+
+					data "pihole_teleporter_backup" "file" {
+					  output_path = %q
+					}
+				`, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.pihole_teleporter_backup.base64", "content_base64"),
+					resource.TestCheckResourceAttrSet("data.pihole_teleporter_backup.base64", "sha256"),
+					resource.TestCheckResourceAttr("data.pihole_teleporter_backup.file", "content_base64", ""),
+					func(*terraform.State) error {
+						_, err := os.Stat(path)
+						return err
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// dataSourceVersion returns a schema resource for reading the installed Pi-hole versions
+func dataSourceVersion() *schema.Resource {
+	return &schema.Resource{
+		Description: "Retrieves the versions of the installed Pi-hole components",
+		ReadContext: dataSourceVersionRead,
+		Schema: map[string]*schema.Schema{
+			"core_version": {
+				Description: "Pi-hole core version, e.g. v6.0.4",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"major_version": {
+				Description: "Major version of Pi-hole core, 0 for development builds without a version",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"web_version": {
+				Description: "Pi-hole web interface version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ftl_version": {
+				Description: "Pi-hole FTL version",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"docker_version": {
+				Description: "Pi-hole Docker image tag, empty when Pi-hole does not run in the official container",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// dataSourceVersionRead reads the installed Pi-hole versions
+func dataSourceVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	version, err := api.NewInfoAPI(client).Version(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	major, _ := version.Major()
+
+	values := map[string]interface{}{
+		"core_version":   version.Core,
+		"major_version":  major,
+		"web_version":    version.Web,
+		"ftl_version":    version.FTL,
+		"docker_version": version.Docker,
+	}
+
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(version.Core)
+
+	return diags
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVersionData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pihole_version" "version" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_version.version", "major_version", "6"),
+					resource.TestMatchResourceAttr("data.pihole_version.version", "core_version", regexp.MustCompile(`^v6\.`)),
+					resource.TestCheckResourceAttrSet("data.pihole_version.version", "ftl_version"),
+					resource.TestCheckResourceAttrSet("data.pihole_version.version", "web_version"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// groupsSchema returns the schema of a set of Pi-hole group IDs an entry is assigned to. Entries without groups
+// are assigned to the default group, so removing all groups from the configuration reassigns the default group.
+func groupsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description + ". Defaults to the default group 0 when unset or empty",
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+	}
+}
+
+// expandGroups converts a Terraform set of group IDs into a list of group IDs, the default group when the set is empty
+func expandGroups(set *schema.Set) []int64 {
+	if set.Len() == 0 {
+		return []int64{defaultGroupID}
+	}
+
+	groups := make([]int64, 0, set.Len())
+
+	for _, id := range set.List() {
+		groups = append(groups, int64(id.(int)))
+	}
+
+	return groups
+}
+
+// flattenGroups converts a list of group IDs into a value to be set on a Terraform set
+func flattenGroups(groups []int64) []interface{} {
+	list := make([]interface{}, len(groups))
+
+	for i, id := range groups {
+		list[i] = int(id)
+	}
+
+	return list
+}
+
+// flattenAssignedGroups converts the group IDs of an entry into a value to be set on its groups, leaving the groups
+// empty when the entry is only assigned to the default group and no groups are set, as the default group is implied
+func flattenAssignedGroups(d *schema.ResourceData, groups []int64) []interface{} {
+	if len(groups) == 1 && groups[0] == defaultGroupID && d.Get("groups").(*schema.Set).Len() == 0 {
+		return []interface{}{}
+	}
+
+	return flattenGroups(groups)
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// instances are the clients of the Pi-hole instances configured for the provider. DNS, CNAME, domain, upstream and
+// reverse server resources apply changes to all instances, other resources manage the first instance only.
+type instances []*api.Client
+
+// metaInstances returns the clients of all configured instances
+func metaInstances(meta interface{}) (instances, bool) {
+	i, ok := meta.(instances)
+
+	return i, ok && len(i) > 0
+}
+
+// metaClient returns the client of the first configured instance
+func metaClient(meta interface{}) (*api.Client, bool) {
+	i, ok := metaInstances(meta)
+	if !ok {
+		return nil, false
+	}
+
+	return i[0], true
+}
+
+// defaultGroupID is the ID of the default group, the only group present with the same ID on every Pi-hole
+const defaultGroupID = 0
+
+// checkGroups rejects group IDs other than the default group for entries applied to multiple instances. Group IDs are
+// assigned by each Pi-hole and groups are only managed on the first instance, so other IDs may be missing or refer to
+// different groups on the other instances.
+func (i instances) checkGroups(groups []int64) error {
+	if !i.multiple() {
+		return nil
+	}
+
+	for _, id := range groups {
+		if id != defaultGroupID {
+			return fmt.Errorf("group %d can't be assigned when multiple urls are configured, group IDs differ between Pi-hole instances and only the default group %d is supported", id, defaultGroupID)
+		}
+	}
+
+	return nil
+}
+
+// multiple reports whether changes are applied to more than one instance
+func (i instances) multiple() bool {
+	return len(i) > 1
+}
+
+// each calls fn with the index and client of all instances concurrently and returns an error diagnostic
+// naming each failed instance
+func (i instances) each(summary string, fn func(n int, client *api.Client) error) (diags diag.Diagnostics) {
+	errs := make([]error, len(i))
+
+	var wg sync.WaitGroup
+	for n, client := range i {
+		wg.Add(1)
+		go func(n int, client *api.Client) {
+			defer wg.Done()
+			errs[n] = fn(n, client)
+		}(n, client)
+	}
+	wg.Wait()
+
+	for n, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if !i.multiple() {
+			return diag.FromErr(err)
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s on %s", summary, i[n].URL()),
+			Detail:   err.Error(),
+		})
+	}
+
+	return diags
+}
+
+// driftWarning returns a warning naming the instance whose resource differs from the other instances
+func driftWarning(client *api.Client, format string, a ...interface{}) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Drift on %s", client.URL()),
+		Detail:   fmt.Sprintf(format, a...),
+	}
+}
+
+// mergeSets merges the set elements read from each instance. Elements present on all instances are kept,
+// elements present on some instances only are kept when they are not part of the prior state, so the next
+// apply removes them, and dropped otherwise, so the next apply creates them again.
+func (i instances) mergeSets(sets [][]map[string]interface{}, prior *schema.Set, describe func(map[string]interface{}) string) ([]map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !i.multiple() {
+		return sets[0], diags
+	}
+
+	var merged []map[string]interface{}
+	found := map[int][]bool{}
+
+	for n, set := range sets {
+		for _, elem := range set {
+			hash := prior.F(elem)
+
+			if _, ok := found[hash]; !ok {
+				found[hash] = make([]bool, len(i))
+				merged = append(merged, elem)
+			}
+
+			found[hash][n] = true
+		}
+	}
+
+	list := make([]map[string]interface{}, 0, len(merged))
+	for _, elem := range merged {
+		partial := false
+
+		for n, ok := range found[prior.F(elem)] {
+			if !ok {
+				partial = true
+				diags = append(diags, driftWarning(i[n], "%s is missing", describe(elem)))
+			}
+		}
+
+		if !partial || !prior.Contains(elem) {
+			list = append(list, elem)
+		}
+	}
+
+	return list, diags
+}
+
+// checkInstance verifies that the instance is reachable, accepts the credentials and runs a supported
+// Pi-hole version, so misconfigurations are reported before the first resource is read
+func checkInstance(ctx context.Context, client *api.Client) diag.Diagnostics {
+	version, err := api.NewInfoAPI(client).Version(ctx)
+
+	var urlErr *url.Error
+
+	switch {
+	case err == nil:
+	case errors.Is(err, api.ErrorTOTPRequired), errors.Is(err, api.ErrorLoginFailed):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to authenticate with %s", client.URL()),
+			Detail:   fmt.Sprintf("%s. Check the password, api_token and totp_secret provider arguments.", err),
+		}}
+	case errors.Is(err, api.ErrorAPINotFound):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("No Pi-hole API found at %s", client.URL()),
+			Detail: fmt.Sprintf("%s. The provider requires Pi-hole v%d or later, check that the URL points to the Pi-hole web server "+
+				"without the /admin path.", err, api.MinimumMajorVersion),
+		}}
+	case errors.As(err, &urlErr):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to reach %s", client.URL()),
+			Detail:   fmt.Sprintf("%s. Check the url, TLS, proxy_url and unix_socket provider arguments.", err),
+		}}
+	default:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to read the Pi-hole version of %s", client.URL()),
+			Detail:   err.Error(),
+		}}
+	}
+
+	major, err := version.Major()
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unknown Pi-hole version of %s", client.URL()),
+			Detail:   fmt.Sprintf("%s, assuming a development build of Pi-hole v%d or later.", err, api.MinimumMajorVersion),
+		}}
+	}
+
+	if major < api.MinimumMajorVersion {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unsupported Pi-hole version at %s", client.URL()),
+			Detail:   fmt.Sprintf("%s: Pi-hole %s is installed, the provider requires Pi-hole v%d or later.", api.ErrorUnsupportedVersion, version.Core, api.MinimumMajorVersion),
+		}}
+	}
+
+	return nil
+}
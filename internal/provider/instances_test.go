@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// testInstances returns clients of unreachable instances with the passed URLs
+func testInstances(t *testing.T, urls ...string) instances {
+	t.Helper()
+
+	clients := make(instances, len(urls))
+	for i, u := range urls {
+		client, err := api.New(api.Config{Config: pihole.Config{BaseURL: u, SessionID: "test"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clients[i] = client
+	}
+
+	return clients
+}
+
+func TestInstancesEach(t *testing.T) {
+	clients := testInstances(t, "http://primary", "http://secondary")
+
+	diags := clients.each("Failed to create DNS record", func(_ int, client *api.Client) error {
+		if client.URL() == "http://secondary" {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+
+	if len(diags) != 1 || diags[0].Severity != diag.Error || diags[0].Summary != "Failed to create DNS record on http://secondary" || diags[0].Detail != "connection refused" {
+		t.Fatalf("unexpected diagnostics %+v", diags)
+	}
+
+	diags = clients[:1].each("Failed to create DNS record", func(int, *api.Client) error {
+		return errors.New("connection refused")
+	})
+
+	if len(diags) != 1 || diags[0].Summary != "connection refused" {
+		t.Fatalf("expected single instance errors to be returned as is, got %+v", diags)
+	}
+}
+
+func TestInstancesMergeSets(t *testing.T) {
+	clients := testInstances(t, "http://primary", "http://secondary")
+
+	elem := func(domain string) map[string]interface{} {
+		return map[string]interface{}{"domain": domain, "ip": "127.0.0.1"}
+	}
+
+	prior := schema.NewSet(schema.HashResource(resourceDNSRecordsSet().Schema["record"].Elem.(*schema.Resource)), []interface{}{
+		elem("both.com"),
+		elem("missing.com"),
+	})
+
+	list, diags := clients.mergeSets([][]map[string]interface{}{
+		{elem("both.com"), elem("missing.com"), elem("extra.com")},
+		{elem("both.com")},
+	}, prior, func(record map[string]interface{}) string {
+		return record["domain"].(string)
+	})
+
+	domains := make([]string, len(list))
+	for i, record := range list {
+		domains[i] = record["domain"].(string)
+	}
+
+	// missing.com is dropped to be created again, extra.com is kept to be removed
+	if strings.Join(domains, ",") != "both.com,extra.com" {
+		t.Fatalf("unexpected merged records %v", domains)
+	}
+
+	if len(diags) != 2 || diags[0].Severity != diag.Warning || diags[0].Summary != "Drift on http://secondary" {
+		t.Fatalf("unexpected diagnostics %+v", diags)
+	}
+}
+
+// versionHandler logs in with any password and responds to all other requests with the version body
+func versionHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/auth" {
+			_, _ = fmt.Fprint(w, `{"session":{"valid":true,"sid":"sid"}}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, body)
+	}
+}
+
+func TestCheckInstance(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	cases := map[string]struct {
+		handler  http.HandlerFunc
+		url      string
+		severity diag.Severity
+		summary  string
+	}{
+		"supported": {
+			handler: versionHandler(`{"version":{"core":{"local":{"version":"v6.0.4"}},"web":{"local":{"version":"v6.0.1"}},"ftl":{"local":{"version":"v6.0.2"}},"docker":{"local":null}}}`),
+		},
+		"development build": {
+			handler:  versionHandler(`{"version":{"core":{"local":{"version":null}}}}`),
+			severity: diag.Warning,
+			summary:  "Unknown Pi-hole version",
+		},
+		"unsupported": {
+			handler:  versionHandler(`{"version":{"core":{"local":{"version":"v5.18.3"}}}}`),
+			severity: diag.Error,
+			summary:  "Unsupported Pi-hole version",
+		},
+		"wrong password": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = fmt.Fprint(w, `{"session":{"valid":false,"message":"password incorrect"}}`)
+			},
+			severity: diag.Error,
+			summary:  "Failed to authenticate",
+		},
+		"no API": {
+			handler:  http.NotFound,
+			severity: diag.Error,
+			summary:  "No Pi-hole API found",
+		},
+		"unreachable": {
+			handler:  http.NotFound,
+			url:      closed.URL,
+			severity: diag.Error,
+			summary:  "Unable to reach",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(c.handler)
+			defer server.Close()
+
+			url := server.URL
+			if c.url != "" {
+				url = c.url
+			}
+
+			client, err := api.New(api.Config{Config: pihole.Config{BaseURL: url, Password: "secret", HttpClient: server.Client()}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags := checkInstance(context.Background(), client)
+
+			if c.summary == "" {
+				if len(diags) != 0 {
+					t.Fatalf("unexpected diagnostics %+v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Severity != c.severity || !strings.HasPrefix(diags[0].Summary, c.summary) {
+				t.Fatalf("unexpected diagnostics %+v", diags)
+			}
+		})
+	}
+}
+
+func TestInstancesCheckGroups(t *testing.T) {
+	clients := testInstances(t, "http://primary", "http://secondary")
+
+	if err := clients.checkGroups([]int64{0}); err != nil {
+		t.Fatalf("expected the default group to be allowed, got %s", err)
+	}
+
+	if err := clients.checkGroups([]int64{0, 3}); err == nil || !strings.Contains(err.Error(), "group 3") {
+		t.Fatalf("expected group 3 to be rejected, got %v", err)
+	}
+
+	if err := clients[:1].checkGroups([]int64{3}); err != nil {
+		t.Fatalf("expected any group to be allowed for a single instance, got %s", err)
+	}
+}
@@ -3,10 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/ryanwholey/terraform-provider-pihole/internal/version"
 )
 
@@ -18,7 +19,84 @@ func Provider() *schema.Provider {
 				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_PASSWORD", nil),
 				Description:  "The admin password used to login to the admin dashboard.",
-				ExactlyOneOf: []string{"password"},
+				ExactlyOneOf: []string{"password", "api_token"},
+			},
+			"api_token": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_API_TOKEN", nil),
+				Description:  "Application password used to login instead of the admin password. Conflicts with password.",
+				ExactlyOneOf: []string{"password", "api_token"},
+			},
+			"totp_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_TOTP_SECRET", nil),
+				Description: "Base32 encoded two-factor authentication secret used to generate login codes when two-factor authentication is enabled. Not needed with an api_token.",
+			},
+			"session_cache_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_SESSION_CACHE_FILE", nil),
+				Description: "File to cache the Pi-hole session in by URL, so it is reused across runs instead of logging in each time. Sessions are logged out when the provider stops if unset",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_MAX_RETRIES", 4),
+				Description:  "Maximum number of retries of failed requests, including requests rate limited by Pi-hole",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_RETRY_WAIT_MIN", "1s"),
+				Description:  "Minimum wait between retries as a duration, e.g. 500ms. A Retry-After header sent by Pi-hole takes precedence",
+				ValidateFunc: validateDuration,
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_RETRY_WAIT_MAX", "30s"),
+				Description:  "Maximum wait between retries as a duration, e.g. 1m. A Retry-After header sent by Pi-hole takes precedence",
+				ValidateFunc: validateDuration,
+			},
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_REQUEST_TIMEOUT", "0s"),
+				Description:  "Timeout of each request attempt as a duration, e.g. 30s, including reading the response. It also limits the streamed output of pihole_gravity_update, so it must exceed the duration of a gravity run. Requests do not time out when 0",
+				ValidateFunc: validateDuration,
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_MAX_CONCURRENT_REQUESTS", 0),
+				Description:  "Maximum number of concurrent requests against Pi-hole, shared by all resources. Requests are not limited when 0",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"proxy_url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_PROXY_URL", nil),
+				Description:   "HTTP, HTTPS or SOCKS5 proxy URL to connect to Pi-hole through, e.g. socks5://localhost:1080. The HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used when unset",
+				ConflictsWith: []string{"unix_socket"},
+			},
+			"unix_socket": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_UNIX_SOCKET", nil),
+				Description:   "Path of a Unix socket to connect to Pi-hole through. The url is still used for the Host header and request paths",
+				ConflictsWith: []string{"proxy_url"},
+			},
+			"urls": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "URLs of multiple Pi-hole instances sharing the same credentials, e.g. a redundant pair. DNS records, CNAME records, domains, upstream DNS servers and reverse servers are applied to and read from all instances, other resources only manage the first instance. Takes precedence over url",
 			},
 			"url": {
 				Type:        schema.TypeString,
@@ -32,16 +110,66 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_CA_FILE", nil),
 				Description: "CA file to connect to Pi-hole with TLS",
 			},
+			"ca_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_CA_PEM", nil),
+				Description:   "PEM encoded CA certificates to connect to Pi-hole with TLS. Conflicts with ca_file",
+				ConflictsWith: []string{"ca_file"},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_INSECURE_SKIP_VERIFY", false),
+				Description: "Skip the verification of Pi-hole's TLS certificate",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_CLIENT_CERT", nil),
+				Description:  "PEM encoded client certificate, or path to it, for reverse proxies requiring mutual TLS",
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_CLIENT_KEY", nil),
+				Description:  "PEM encoded client certificate key, or path to it, for reverse proxies requiring mutual TLS",
+				RequiredWith: []string{"client_cert"},
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_TLS_SERVER_NAME", nil),
+				Description: "Server name to verify Pi-hole's TLS certificate against, instead of the host of the URL",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"pihole_cname_records": dataSourceCNAMERecords(),
-			"pihole_dns_records":   dataSourceDNSRecords(),
+			"pihole_cname_records":     dataSourceCNAMERecords(),
+			"pihole_dns_records":       dataSourceDNSRecords(),
+			"pihole_groups":            dataSourceGroups(),
+			"pihole_teleporter_backup": dataSourceTeleporterBackup(),
+			"pihole_version":           dataSourceVersion(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"pihole_cname_record": resourceCNAMERecord(),
-			"pihole_dns_record":   resourceDNSRecord(),
+			"pihole_adlist":             resourceAdlist(),
+			"pihole_blocking":           resourceBlocking(),
+			"pihole_client":             resourceClient(),
+			"pihole_cname_record":       resourceCNAMERecord(),
+			"pihole_cname_records_set":  resourceCNAMERecordsSet(),
+			"pihole_config":             resourceConfig(),
+			"pihole_dns_record":         resourceDNSRecord(),
+			"pihole_dns_records_set":    resourceDNSRecordsSet(),
+			"pihole_dns_upstreams":      resourceDNSUpstreams(),
+			"pihole_domain":             resourceDomain(),
+			"pihole_gravity_update":     resourceGravityUpdate(),
+			"pihole_group":              resourceGroup(),
+			"pihole_restart_dns":        resourceRestartDNS(),
+			"pihole_reverse_server":     resourceReverseServer(),
+			"pihole_teleporter_restore": resourceTeleporterRestore(),
 		},
 	}
 
@@ -53,18 +181,84 @@ func Provider() *schema.Provider {
 // configure configures a Pi-hole client to be used for terraform resource requests
 func configure(version string, provider *schema.Provider) func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (client interface{}, diags diag.Diagnostics) {
-		client, err := Config{
-			Password:  d.Get("password").(string),
-			URL:       d.Get("url").(string),
-			UserAgent: provider.UserAgent("terraform-provider-pihole", version),
-			CAFile:    d.Get("ca_file").(string),
-			SessionID: os.Getenv("__PIHOLE_SESSION_ID"),
-		}.Client(ctx)
-
-		if err != nil {
-			return nil, diag.FromErr(fmt.Errorf("failed to instantiate client: %w", err))
+		durations := map[string]time.Duration{}
+		for _, key := range []string{"retry_wait_min", "retry_wait_max", "request_timeout"} {
+			duration, err := time.ParseDuration(d.Get(key).(string))
+			if err != nil {
+				return nil, diag.Errorf("invalid %s: %s", key, err)
+			}
+
+			durations[key] = duration
+		}
+
+		config := Config{
+			Password:              d.Get("password").(string),
+			APIToken:              d.Get("api_token").(string),
+			TOTPSecret:            d.Get("totp_secret").(string),
+			UserAgent:             provider.UserAgent("terraform-provider-pihole", version),
+			CAFile:                d.Get("ca_file").(string),
+			CAPEM:                 d.Get("ca_pem").(string),
+			InsecureSkipVerify:    d.Get("insecure_skip_verify").(bool),
+			ClientCert:            d.Get("client_cert").(string),
+			ClientKey:             d.Get("client_key").(string),
+			TLSServerName:         d.Get("tls_server_name").(string),
+			MaxRetries:            d.Get("max_retries").(int),
+			RetryWaitMin:          durations["retry_wait_min"],
+			RetryWaitMax:          durations["retry_wait_max"],
+			RequestTimeout:        durations["request_timeout"],
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+			ProxyURL:              d.Get("proxy_url").(string),
+			UnixSocket:            d.Get("unix_socket").(string),
+			SessionCacheFile:      d.Get("session_cache_file").(string),
+		}
+
+		urls := []string{d.Get("url").(string)}
+		if raw := d.Get("urls").([]interface{}); len(raw) > 0 {
+			urls = make([]string, 0, len(raw))
+			for _, u := range raw {
+				urls = append(urls, u.(string))
+			}
 		}
 
-		return client, diags
+		clients := make(instances, 0, len(urls))
+		for _, u := range urls {
+			config.URL = u
+
+			c, err := config.Client(ctx)
+			if err != nil {
+				return nil, diag.FromErr(fmt.Errorf("failed to instantiate client for %s: %w", u, err))
+			}
+
+			registerClient(c)
+			clients = append(clients, c)
+		}
+
+		// Values which are unknown during planning, e.g. the URL of a Pi-hole created in the same run, are
+		// checked once they are known
+		if !d.GetRawConfig().IsWhollyKnown() {
+			return clients, diags
+		}
+
+		for _, c := range clients {
+			diags = append(diags, checkInstance(ctx, c)...)
+		}
+
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		return clients, diags
 	}
 }
+
+// validateDuration validates that a string is a non negative Go duration, e.g. 30s
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	duration, err := time.ParseDuration(v.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("expected %s to be a duration, e.g. 30s: %w", k, err))
+	} else if duration < 0 {
+		es = append(es, fmt.Errorf("expected %s to not be negative, got %s", k, duration))
+	}
+
+	return ws, es
+}
@@ -1,8 +1,8 @@
 package provider
 
 import (
-	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,26 +15,16 @@ func testAccPreCheck(t *testing.T) {
 	}
 
 	password := os.Getenv("PIHOLE_PASSWORD")
-	if password == "" {
-		t.Fatal("PIHOLE_PASSWORD must be set for acceptance tests")
+	apiToken := os.Getenv("PIHOLE_API_TOKEN")
+	if password == "" && apiToken == "" {
+		t.Fatal("PIHOLE_PASSWORD or PIHOLE_API_TOKEN must be set for acceptance tests")
 	}
 
-	if v := os.Getenv("__PIHOLE_SESSION_ID"); v == "" {
-		t.Log("No session ID found, setting for testing")
+	// Share a session between test cases instead of logging in for each provider instance
+	if v := os.Getenv("PIHOLE_SESSION_CACHE_FILE"); v == "" {
+		t.Log("No session cache file found, setting for testing")
 
-		client, err := Config{
-			URL:      url,
-			Password: password,
-		}.Client(context.TODO())
-
-		if err != nil {
-			t.Fatal(err.Error())
-		}
-		session, err := client.SessionAPI.Post(context.TODO())
-		if err != nil {
-			t.Fatal(err.Error())
-		}
-		if err := os.Setenv("__PIHOLE_SESSION_ID", session.SID); err != nil {
+		if err := os.Setenv("PIHOLE_SESSION_CACHE_FILE", filepath.Join(os.TempDir(), "terraform-provider-pihole-test-sessions.json")); err != nil {
 			t.Fatal(err.Error())
 		}
 	}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceAdlist returns the subscribed list Terraform resource management configuration
+func resourceAdlist() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole gravity block or allow list subscription",
+		CreateContext: resourceAdlistCreate,
+		ReadContext:   resourceAdlistRead,
+		UpdateContext: resourceAdlistUpdate,
+		DeleteContext: resourceAdlistDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAdlistImport,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceAdlistV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceAdlistStateUpgradeV0,
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Description:  "Address of the list",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"type": {
+				Description:  "Whether the list's domains are blocked or allowed. Must be one of block or allow",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      api.ListTypeBlock,
+				ValidateFunc: validation.StringInSlice([]string{api.ListTypeBlock, api.ListTypeAllow}, false),
+			},
+			"comment": {
+				Description: "Comment describing the list",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the list is enabled",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"groups": groupsSchema("IDs of the groups the list applies to"),
+			"number_of_domains": {
+				Description: "Number of domains on the list as of the last gravity update",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"invalid_domains": {
+				Description: "Number of invalid domains on the list as of the last gravity update",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"abp_entries": {
+				Description: "Number of Adblock Plus style entries on the list as of the last gravity update",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"date_updated": {
+				Description: "RFC 3339 timestamp of the last gravity update of the list, empty if the list was never downloaded",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the last gravity update of the list. 1 when downloaded, 2 when unchanged, 3 when using a cached copy and 4 when failed",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceAdlistV0 returns the version 0 schema of the subscribed list resource, which was identified by its address only
+func resourceAdlistV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+// resourceAdlistStateUpgradeV0 migrates address IDs to type/address IDs
+func resourceAdlistStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	address, _ := rawState["address"].(string)
+	if address == "" {
+		address, _ = rawState["id"].(string)
+	}
+
+	listType, _ := rawState["type"].(string)
+	if listType == "" {
+		listType = api.ListTypeBlock
+	}
+
+	rawState["id"] = adlistID(listType, address)
+
+	return rawState, nil
+}
+
+// adlistID returns the Terraform ID of a subscribed list
+func adlistID(listType string, address string) string {
+	return fmt.Sprintf("%s/%s", listType, address)
+}
+
+// parseAdlistID splits a subscribed list ID into its type and address
+func parseAdlistID(id string) (string, string, error) {
+	listType, address, ok := strings.Cut(id, "/")
+	if !ok || (listType != api.ListTypeBlock && listType != api.ListTypeAllow) || address == "" {
+		return "", "", fmt.Errorf("unexpected list ID %q, expected type/address with a type of block or allow", id)
+	}
+
+	return listType, address, nil
+}
+
+// expandAdlist builds a subscribed list from the resource configuration
+func expandAdlist(d *schema.ResourceData) api.List {
+	return api.List{
+		Address: d.Get("address").(string),
+		Type:    d.Get("type").(string),
+		Comment: d.Get("comment").(string),
+		Enabled: d.Get("enabled").(bool),
+		Groups:  expandGroups(d.Get("groups").(*schema.Set)),
+	}
+}
+
+// resourceAdlistCreate handles the subscription to a list via Terraform
+func resourceAdlistCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	list, err := api.NewListAPI(client).Create(ctx, expandAdlist(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(adlistID(list.Type, list.Address))
+
+	return resourceAdlistRead(ctx, d, meta)
+}
+
+// resourceAdlistRead finds a subscribed list based on its type/address ID
+func resourceAdlistRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	listType, address, err := parseAdlistID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list, err := api.NewListAPI(client).Get(ctx, address, listType)
+	if err != nil {
+		if errors.Is(err, api.ErrorListNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("address", list.Address); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("type", list.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", list.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("enabled", list.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("groups", flattenAssignedGroups(d, list.Groups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("number_of_domains", list.Number); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("invalid_domains", list.InvalidDomains); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("abp_entries", list.ABPEntries); err != nil {
+		return diag.FromErr(err)
+	}
+
+	dateUpdated := ""
+	if !list.DateUpdated.IsZero() {
+		dateUpdated = list.DateUpdated.Format(time.RFC3339)
+	}
+
+	if err = d.Set("date_updated", dateUpdated); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("status", list.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceAdlistUpdate handles in place updates of a subscribed list via Terraform
+func resourceAdlistUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	listType, _, err := parseAdlistID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list, err := api.NewListAPI(client).Update(ctx, listType, expandAdlist(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(adlistID(list.Type, list.Address))
+
+	return resourceAdlistRead(ctx, d, meta)
+}
+
+// resourceAdlistDelete handles the unsubscription from a list via Terraform
+func resourceAdlistDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	listType, address, err := parseAdlistID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := api.NewListAPI(client).Delete(ctx, address, listType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceAdlistImport validates the type/address ID of an imported list
+func resourceAdlistImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseAdlistID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+const testAdlistAddress = "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts"
+
+// TestAccAdlist acceptance test for the subscribed list resource
+func TestAccAdlist(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAdlistDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAdlistResourceConfig("hosts", testAdlistAddress, "block", "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "id", "block/"+testAdlistAddress),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "address", testAdlistAddress),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "type", "block"),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "comment", "first"),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "enabled", "true"),
+					resource.TestCheckResourceAttrSet("pihole_adlist.hosts", "number_of_domains"),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "groups.#", "0"),
+					testCheckAdlistResourceExists(t, testAdlistAddress, "block", "first"),
+				),
+			},
+			{
+				Config: testAdlistResourceConfig("hosts", testAdlistAddress, "allow", "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "id", "allow/"+testAdlistAddress),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "type", "allow"),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "comment", "second"),
+					resource.TestCheckResourceAttr("pihole_adlist.hosts", "enabled", "false"),
+					testCheckAdlistResourceExists(t, testAdlistAddress, "allow", "second"),
+				),
+			},
+			{
+				ResourceName:      "pihole_adlist.hosts",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testAdlistResourceConfig returns HCL to configure a subscribed list
+func testAdlistResourceConfig(name string, address string, listType string, comment string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pihole_adlist" %q {
+			address = %q
+			type    = %q
+			comment = %q
+			enabled = %t
+		}
+	`, name, address, listType, comment, enabled)
+}
+
+// testCheckAdlistResourceExists checks that the list exists in Pi-hole
+func testCheckAdlistResourceExists(_ *testing.T, address string, listType string, comment string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(instances)[0]
+
+		list, err := api.NewListAPI(client).Get(context.Background(), address, listType)
+		if err != nil {
+			return err
+		}
+
+		if list.Comment != comment {
+			return fmt.Errorf("requested list %s comment %q does not match: %q", address, comment, list.Comment)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAdlistDestroy checks that all subscribed list resources have been deleted
+func testAccCheckAdlistDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(instances)[0]
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_adlist" {
+			continue
+		}
+
+		listType, address, err := parseAdlistID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := api.NewListAPI(client).Get(context.Background(), address, listType); err != nil {
+			if !errors.Is(err, api.ErrorListNotFound) {
+				return err
+			}
+		} else {
+			return fmt.Errorf("list %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestResourceAdlistStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		state    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			state:    map[string]interface{}{"id": testAdlistAddress, "address": testAdlistAddress, "type": "allow"},
+			expected: map[string]interface{}{"id": "allow/" + testAdlistAddress, "address": testAdlistAddress, "type": "allow"},
+		},
+		{
+			state:    map[string]interface{}{"id": testAdlistAddress},
+			expected: map[string]interface{}{"id": "block/" + testAdlistAddress},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := resourceAdlistStateUpgradeV0(context.Background(), c.state, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+const (
+	// blockingID is the ID of the single global blocking state of a Pi-hole
+	blockingID = "blocking"
+)
+
+// resourceBlocking returns the global blocking state Terraform resource management configuration
+func resourceBlocking() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the global Pi-hole blocking state. Blocking is enabled again when the resource is destroyed",
+		CreateContext: resourceBlockingCreate,
+		ReadContext:   resourceBlockingRead,
+		UpdateContext: resourceBlockingUpdate,
+		DeleteContext: resourceBlockingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBlockingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Description: "Whether blocking is enabled",
+				Type:        schema.TypeBool,
+				Required:    true,
+			},
+			"timer": {
+				Description:  "Seconds after which Pi-hole reverts the blocking state. Once a timer started by the resource expired, the reverted state is not reported as drift, replace the resource to start another timer",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"status": {
+				Description: "Current blocking status, one of enabled, disabled, failed or unknown",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"remaining_timer": {
+				Description: "Seconds until Pi-hole reverts the blocking state, 0 when no timer is running",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"timer_expires_at": {
+				Description: "RFC 3339 timestamp at which the timer started by the resource expires, empty if no timer was started",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// setBlocking sets the configured blocking state and timer, recording when a started timer expires
+func setBlocking(ctx context.Context, client *api.Client, d *schema.ResourceData) error {
+	timer := d.Get("timer").(int)
+
+	if _, err := api.NewBlockingAPI(client).Set(ctx, d.Get("enabled").(bool), timer); err != nil {
+		return err
+	}
+
+	expiresAt := ""
+	if timer > 0 {
+		expiresAt = time.Now().Add(time.Duration(timer) * time.Second).UTC().Format(time.RFC3339)
+	}
+
+	return d.Set("timer_expires_at", expiresAt)
+}
+
+// timerReverted reports whether blocking is in the state produced by the expiry of a timer the resource started,
+// the opposite of the configured state
+func timerReverted(d *schema.ResourceData, blocking *api.Blocking) bool {
+	expiresAt, err := time.Parse(time.RFC3339, d.Get("timer_expires_at").(string))
+	if err != nil {
+		return false
+	}
+
+	return !time.Now().Before(expiresAt) && blocking.Timer == 0 && blocking.Enabled() != d.Get("enabled").(bool)
+}
+
+// resourceBlockingCreate handles setting the blocking state via Terraform
+func resourceBlockingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := setBlocking(ctx, client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(blockingID)
+
+	return resourceBlockingRead(ctx, d, meta)
+}
+
+// resourceBlockingRead retrieves the blocking state, exposing blocking left in another state as drift
+func resourceBlockingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	blocking, err := api.NewBlockingAPI(client).Get(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The state reverted by an expired timer the resource started is the expected outcome of the configuration
+	if !timerReverted(d, blocking) {
+		if err := d.Set("enabled", blocking.Enabled()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("status", blocking.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("remaining_timer", blocking.Timer); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceBlockingUpdate handles changes of the blocking state or timer via Terraform
+func resourceBlockingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := setBlocking(ctx, client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBlockingRead(ctx, d, meta)
+}
+
+// resourceBlockingDelete enables blocking again via Terraform
+func resourceBlockingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if _, err := api.NewBlockingAPI(client).Set(ctx, true, 0); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceBlockingImport imports the blocking state regardless of the passed ID
+func resourceBlockingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(blockingID)
+
+	return []*schema.ResourceData{d}, nil
+}
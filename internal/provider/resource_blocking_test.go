@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccBlocking acceptance test for the global blocking resource
+func TestAccBlocking(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testBlockingResourceConfig(false, 600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_blocking.blocking", "enabled", "false"),
+					resource.TestCheckResourceAttr("pihole_blocking.blocking", "status", "disabled"),
+					resource.TestCheckResourceAttrSet("pihole_blocking.blocking", "remaining_timer"),
+					testCheckBlocking(false),
+				),
+			},
+			{
+				Config: testBlockingResourceConfig(true, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_blocking.blocking", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_blocking.blocking", "remaining_timer", "0"),
+					testCheckBlocking(true),
+				),
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(instances)[0]
+
+					if _, err := api.NewBlockingAPI(client).Set(context.Background(), false, 0); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testBlockingResourceConfig(true, 0),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testBlockingResourceConfig(true, 0),
+				Check:  testCheckBlocking(true),
+			},
+			{
+				ResourceName:      "pihole_blocking.blocking",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testBlockingResourceConfig returns HCL to configure the blocking state
+func testBlockingResourceConfig(enabled bool, timer int) string {
+	return fmt.Sprintf(`
+		resource "pihole_blocking" "blocking" {
+			enabled = %t
+			timer   = %d
+		}
+	`, enabled, timer)
+}
+
+// testCheckBlocking checks the blocking state of Pi-hole
+func testCheckBlocking(enabled bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(instances)[0]
+
+		blocking, err := api.NewBlockingAPI(client).Get(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if blocking.Enabled() != enabled {
+			return fmt.Errorf("expected blocking enabled %t, got status %s", enabled, blocking.Status)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckBlockingDestroy checks that blocking is enabled again after the resource is destroyed
+func testAccCheckBlockingDestroy(s *terraform.State) error {
+	return testCheckBlocking(true)(s)
+}
+
+func TestTimerReverted(t *testing.T) {
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	running := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+
+	cases := []struct {
+		expiresAt string
+		blocking  api.Blocking
+		expected  bool
+	}{
+		{expiresAt: expired, blocking: api.Blocking{Status: api.BlockingEnabled}, expected: true},
+		{expiresAt: "", blocking: api.Blocking{Status: api.BlockingEnabled}, expected: false},
+		{expiresAt: running, blocking: api.Blocking{Status: api.BlockingEnabled}, expected: false},
+		{expiresAt: expired, blocking: api.Blocking{Status: api.BlockingDisabled}, expected: false},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceBlocking().Schema, map[string]interface{}{"enabled": false, "timer": 60})
+		if err := d.Set("timer_expires_at", c.expiresAt); err != nil {
+			t.Fatal(err)
+		}
+
+		if actual := timerReverted(d, &c.blocking); actual != c.expected {
+			t.Errorf("expected reverted %t for timer expiring at %q and status %s, got %t", c.expected, c.expiresAt, c.blocking.Status, actual)
+		}
+	}
+}
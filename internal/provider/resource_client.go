@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+var (
+	hostnameRegexp  = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$`)
+	interfaceRegexp = regexp.MustCompile(`^:[a-zA-Z0-9_.@-]{1,15}$`)
+)
+
+// resourceClient returns the client Terraform resource management configuration
+func resourceClient() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole client and its group assignment",
+		CreateContext: resourceClientCreate,
+		ReadContext:   resourceClientRead,
+		UpdateContext: resourceClientUpdate,
+		DeleteContext: resourceClientDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"client": {
+				Description:      "Client identifier. Either an IP address, a MAC address, a hostname, a subnet in CIDR notation or an interface prefixed with a colon (e.g. :eth0)",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateClientIdentifier,
+				DiffSuppressFunc: suppressCaseDiff,
+			},
+			"comment": {
+				Description: "Comment describing the client",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"groups": groupsSchema("IDs of the groups the client is assigned to"),
+			"name": {
+				Description: "Hostname of the client as last seen by Pi-hole",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// validateClientIdentifier checks that a client is identified by an IP, MAC, hostname, subnet or interface
+func validateClientIdentifier(v interface{}, k string) (warnings []string, errs []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %q to be a string", k)}
+	}
+
+	if net.ParseIP(value) != nil {
+		return nil, nil
+	}
+
+	if _, _, err := net.ParseCIDR(value); err == nil {
+		return nil, nil
+	}
+
+	if hw, err := net.ParseMAC(value); err == nil && len(hw) == 6 && strings.Contains(value, ":") {
+		return nil, nil
+	}
+
+	if interfaceRegexp.MatchString(value) {
+		return nil, nil
+	}
+
+	if len(value) <= 253 && hostnameRegexp.MatchString(value) {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("expected %q to be an IP address, MAC address, hostname, CIDR subnet or :interface, got %q", k, value)}
+}
+
+// suppressCaseDiff ignores differences in letter case, e.g. for MAC addresses normalized by Pi-hole
+func suppressCaseDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// expandClient builds a client from the resource configuration
+func expandClient(d *schema.ResourceData) api.ClientEntry {
+	return api.ClientEntry{
+		Client:  d.Get("client").(string),
+		Comment: d.Get("comment").(string),
+		Groups:  expandGroups(d.Get("groups").(*schema.Set)),
+	}
+}
+
+// resourceClientCreate handles the creation of a client via Terraform
+func resourceClientCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	c, err := api.NewClientAPI(client).Create(ctx, expandClient(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(c.Client)
+
+	return resourceClientRead(ctx, d, meta)
+}
+
+// resourceClientRead finds a client based on its identifier
+func resourceClientRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	c, err := api.NewClientAPI(client).Get(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, api.ErrorClientNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("client", c.Client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", c.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("groups", flattenAssignedGroups(d, c.Groups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", c.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceClientUpdate handles in place updates of a client's comment and groups via Terraform
+func resourceClientUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	c := expandClient(d)
+	c.Client = d.Id()
+
+	if _, err := api.NewClientAPI(client).Update(ctx, c); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClientRead(ctx, d, meta)
+}
+
+// resourceClientDelete handles the deletion of a client via Terraform
+func resourceClientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := api.NewClientAPI(client).Delete(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
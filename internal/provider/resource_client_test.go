@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccClient acceptance test for the client resource
+func TestAccClient(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testClientResourceConfig("tablet", "192.168.1.50", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.tablet", "client", "192.168.1.50"),
+					resource.TestCheckResourceAttr("pihole_client.tablet", "comment", "first"),
+					resource.TestCheckResourceAttr("pihole_client.tablet", "groups.#", "2"),
+					testCheckClientResourceExists(t, "192.168.1.50", "first"),
+				),
+			},
+			{
+				Config: testClientResourceConfig("tablet", "192.168.1.50", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.tablet", "comment", "second"),
+					testCheckClientResourceExists(t, "192.168.1.50", "second"),
+				),
+			},
+			{
+				ResourceName:      "pihole_client.tablet",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testClientResourceConfig returns HCL to configure a client assigned to a group
+func testClientResourceConfig(name string, client string, comment string) string {
+	return fmt.Sprintf(`
+		resource "pihole_group" %[1]q {
+			name = %[1]q
+		}
+
+		resource "pihole_client" %[1]q {
+			client  = %[2]q
+			comment = %[3]q
+			groups  = [0, pihole_group.%[1]s.id]
+		}
+	`, name, client, comment)
+}
+
+// testCheckClientResourceExists checks that the client exists in Pi-hole
+func testCheckClientResourceExists(_ *testing.T, client string, comment string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		c, err := api.NewClientAPI(testAccProvider.Meta().(instances)[0]).Get(context.Background(), client)
+		if err != nil {
+			return err
+		}
+
+		if c.Comment != comment {
+			return fmt.Errorf("requested client %s comment %q does not match: %q", client, comment, c.Comment)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckClientDestroy checks that all client resources have been deleted
+func testAccCheckClientDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(instances)[0]
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_client" {
+			continue
+		}
+
+		if _, err := api.NewClientAPI(client).Get(context.Background(), r.Primary.ID); err != nil {
+			if !errors.Is(err, api.ErrorClientNotFound) {
+				return err
+			}
+		} else {
+			return fmt.Errorf("client %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestValidateClientIdentifier(t *testing.T) {
+	valid := []string{
+		"192.168.1.50",
+		"fe80::1",
+		"192.168.1.0/24",
+		"fd00::/64",
+		"12:34:56:78:9A:BC",
+		"tablet",
+		"tablet.lan",
+		":eth0",
+	}
+
+	for _, v := range valid {
+		if _, errs := validateClientIdentifier(v, "client"); len(errs) != 0 {
+			t.Errorf("expected %q to be valid, got %v", v, errs)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"192.168.1.0/33",
+		"-tablet",
+		"tablet_1",
+		":",
+		"12:34:56:78:9A",
+	}
+
+	for _, v := range invalid {
+		if _, errs := validateClientIdentifier(v, "client"); len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", v)
+		}
+	}
+}
@@ -3,21 +3,21 @@ package provider
 import (
 	"context"
 	"errors"
-	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
-var resourceDeleteMutex sync.Mutex
-
 // resourceCNAMERecord returns the CNAME Terraform resource management configuration
 func resourceCNAMERecord() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manages a Pi-hole CNAME record",
 		CreateContext: resourceCNAMERecordCreate,
 		ReadContext:   resourceCNAMERecordRead,
+		UpdateContext: resourceCNAMERecordUpdate,
 		DeleteContext: resourceCNAMERecordDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -33,75 +33,168 @@ func resourceCNAMERecord() *schema.Resource {
 				Description: "Value of the CNAME record where traffic will be directed to from the configured domain value",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+			},
+			"ttl": {
+				Description:  "TTL in seconds of the CNAME record. Pi-hole's default TTL is used when unset",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 		},
 	}
 }
 
 // resourceCNAMERecordCreate handles the creation a CNAME record via Terraform
-func resourceCNAMERecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+func resourceCNAMERecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
 	domain := d.Get("domain").(string)
 	target := d.Get("target").(string)
+	ttl := d.Get("ttl").(int)
 
-	_, err := client.LocalCNAME.Create(ctx, domain, target)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	diags := clients.each("Failed to create CNAME record", func(_ int, client *api.Client) error {
+		cnameAPI := api.NewLocalCNAMEAPI(client)
 
-	d.SetId(domain)
+		// Records already present on some instances of a redundant setup are adopted
+		if clients.multiple() {
+			_, err := cnameAPI.Update(ctx, domain, target, ttl)
+			if !errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
+				return err
+			}
+		}
+
+		_, err := cnameAPI.Create(ctx, domain, target, ttl)
+		return err
+	})
+
+	if !diags.HasError() {
+		d.SetId(domain)
+	}
 
 	return diags
 }
 
 // resourceCNAMERecordRead retrieves the CNAME record of the associated domain ID
-func resourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+func resourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	record, err := client.LocalCNAME.Get(ctx, d.Id())
-	if err != nil {
+	records := make([]*pihole.CNAMERecord, len(clients))
+
+	diags := clients.each("Failed to read CNAME record", func(n int, client *api.Client) error {
+		record, err := api.NewLocalCNAMEAPI(client).Get(ctx, d.Id())
 		if errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
-			d.SetId("")
 			return nil
 		}
 
+		records[n] = record
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	// Records are compared to the state, or to the first instance's record after an import. A differing
+	// record on any instance is written to state so the next apply updates it.
+	target, ttl := d.Get("target").(string), d.Get("ttl").(int)
+	if d.Get("domain").(string) == "" {
+		for _, record := range records {
+			if record != nil {
+				target, ttl = record.Target, record.TTL
+				break
+			}
+		}
+	}
+
+	var found *pihole.CNAMERecord
+	missing := false
+	for n, record := range records {
+		if record == nil {
+			missing = true
+			if clients.multiple() {
+				diags = append(diags, driftWarning(clients[n], "CNAME record %s is missing and will be created", d.Id()))
+			}
+			continue
+		}
+
+		drifted := record.Target != target || record.TTL != ttl
+		if drifted && clients.multiple() {
+			diags = append(diags, driftWarning(clients[n], "CNAME record %s has target %s and TTL %d", record.Domain, record.Target, record.TTL))
+		}
+
+		if found == nil || drifted {
+			found = record
+		}
+	}
+
+	// A record missing on any instance is removed from state, so the next apply creates it again
+	if missing {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("domain", found.Domain); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("domain", record.Domain); err != nil {
+	if err := d.Set("target", found.Target); err != nil {
 		return diag.FromErr(err)
 	}
 
-	if err = d.Set("target", record.Target); err != nil {
+	if err := d.Set("ttl", found.TTL); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return diags
 }
 
-// resourceCNAMERecordDelete handles the deletion of a CNAME record via Terraform
-func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+// resourceCNAMERecordUpdate handles in place target and TTL changes of a CNAME record, swapping them in a single write
+func resourceCNAMERecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	resourceDeleteMutex.Lock()
-	defer resourceDeleteMutex.Unlock()
+	target := d.Get("target").(string)
+	ttl := d.Get("ttl").(int)
 
-	if err := client.LocalCNAME.Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	diags := clients.each("Failed to update CNAME record", func(_ int, client *api.Client) error {
+		cnameAPI := api.NewLocalCNAMEAPI(client)
+
+		_, err := cnameAPI.Update(ctx, d.Id(), target, ttl)
+		if errors.Is(err, pihole.ErrorLocalCNAMENotFound) && clients.multiple() {
+			_, err = cnameAPI.Create(ctx, d.Id(), target, ttl)
+		}
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceCNAMERecordRead(ctx, d, meta)
+}
+
+// resourceCNAMERecordDelete handles the deletion of a CNAME record via Terraform
+func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
 	}
 
-	d.SetId("")
+	diags := clients.each("Failed to delete CNAME record", func(_ int, client *api.Client) error {
+		return api.NewLocalCNAMEAPI(client).Delete(ctx, d.Id())
+	})
+
+	if !diags.HasError() {
+		d.SetId("")
+	}
 
 	return diags
 }
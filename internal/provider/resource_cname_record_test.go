@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
 // TestAccCNAMERecord acceptance test for the CNAME record resource
@@ -29,21 +30,35 @@ func TestAccCNAMERecord(t *testing.T) {
 			{
 				Config: testLocalCNAMEResourceConfig("foo", "foo.com", "woz.com"),
 				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_cname_record.foo", "id", "foo.com"),
 					resource.TestCheckResourceAttr("pihole_cname_record.foo", "domain", "foo.com"),
 					resource.TestCheckResourceAttr("pihole_cname_record.foo", "target", "woz.com"),
 					testCheckLocalCNAMEResourceExists(t, "foo.com", "woz.com"),
 				),
 			},
-			// TOOD: Uncomment after addressing client performance issues regarding one off requests. Consider a bulk update implementation.
-			// {
-			// 	Config: testLocalCNAMEResourceWithDataConfig(),
-			// 	Check: resource.ComposeTestCheckFunc(
-			// 		resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.#", "20"),
-
-			// 		resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.domain", "aa.com"),
-			// 		resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.0.target", "ingress.example.local"),
-			// 	),
-			// },
+			{
+				Config: testLocalCNAMEResourceWithTTLConfig("foo", "foo.com", "woz.com", 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_cname_record.foo", "id", "foo.com"),
+					resource.TestCheckResourceAttr("pihole_cname_record.foo", "ttl", "60"),
+					testCheckLocalCNAMEResourceExists(t, "foo.com", "woz.com"),
+				),
+			},
+			{
+				ResourceName:      "pihole_cname_record.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testLocalCNAMEResourceWithTTLConfig("foo", "foo.com", "woz.com", 60) + testLocalCNAMEResourceWithDataConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_cname_records.records", "records.#", "21"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.pihole_cname_records.records", "records.*", map[string]string{
+						"domain": "aa.com",
+						"target": "ingress.example.local",
+					}),
+				),
+			},
 		},
 	})
 }
@@ -58,51 +73,63 @@ func testLocalCNAMEResourceConfig(name string, domain string, target string) str
 	`, name, domain, target)
 }
 
-// func testLocalCNAMEResourceWithDataConfig() string {
-// 	return `
-// 		locals {
-// 		  all_cnames = [
-// 			"aa.com",
-// 			"bb.com",
-// 			"cc.com",
-// 			"dd.com",
-// 			"ee.com",
-// 			"ff.com",
-// 			"gg.com",
-// 			"hh.com",
-// 			"ii.com",
-// 			"jj.com",
-// 			"kk.com",
-// 			"ll.com",
-// 			"mm.com",
-// 			"nn.com",
-// 			"oo.com",
-// 			"pp.com",
-// 			"qq.com",
-// 			"rr.com",
-// 			"ss.com",
-// 			"tt.com",
-// 		  ]
-// 		}
+// testLocalCNAMEResourceWithTTLConfig returns HCL to configure a CNAME record with a TTL
+func testLocalCNAMEResourceWithTTLConfig(name string, domain string, target string, ttl int) string {
+	return fmt.Sprintf(`
+		resource "pihole_cname_record" %q {
+			domain = %q
+			target = %q
+			ttl    = %d
+		}
+	`, name, domain, target, ttl)
+}
 
-// 		resource "pihole_cname_record" "cname_records" {
-// 		  count  = length(local.all_cnames)
-// 		  domain = local.all_cnames[count.index]
-// 		  target = "ingress.example.local"
-// 		}
+// testLocalCNAMEResourceWithDataConfig returns HCL to configure 20 CNAME records, written concurrently, and list them
+func testLocalCNAMEResourceWithDataConfig() string {
+	return `
+		locals {
+		  all_cnames = [
+			"aa.com",
+			"bb.com",
+			"cc.com",
+			"dd.com",
+			"ee.com",
+			"ff.com",
+			"gg.com",
+			"hh.com",
+			"ii.com",
+			"jj.com",
+			"kk.com",
+			"ll.com",
+			"mm.com",
+			"nn.com",
+			"oo.com",
+			"pp.com",
+			"qq.com",
+			"rr.com",
+			"ss.com",
+			"tt.com",
+		  ]
+		}
+
+		resource "pihole_cname_record" "cname_records" {
+		  count  = length(local.all_cnames)
+		  domain = local.all_cnames[count.index]
+		  target = "ingress.example.local"
+		}
 
-// 		data "pihole_cname_records" "records" {
-// 		  depends_on = [pihole_cname_record.cname_records]
-// 		}
-//     `
-// }
+		data "pihole_cname_records" "records" {
+		  depends_on = [pihole_cname_record.foo, pihole_cname_record.cname_records]
+		}
+	`
+}
 
 // testCheckLocalCNAMEResourceExists checks that the CNAME record exists in Pi-hole
 func testCheckLocalCNAMEResourceExists(_ *testing.T, domain string, target string) resource.TestCheckFunc {
 	return func(*terraform.State) error {
-		client := testAccProvider.Meta().(*pihole.Client)
+		client := testAccProvider.Meta().(instances)[0]
 
-		record, err := client.LocalCNAME.Get(context.Background(), domain)
+		record, err := api.NewLocalCNAMEAPI(client).Get(context.Background(), domain)
 		if err != nil {
 			return err
 		}
@@ -117,14 +144,14 @@ func testCheckLocalCNAMEResourceExists(_ *testing.T, domain string, target strin
 
 // testAccCheckCNAMERecordDestroy checks that all resources have been deleted
 func testAccCheckCNAMERecordDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*pihole.Client)
+	client := testAccProvider.Meta().(instances)[0]
 
 	for _, r := range s.RootModule().Resources {
 		if r.Type != "pihole_cname_record" {
 			continue
 		}
 
-		if _, err := client.LocalCNAME.Get(context.Background(), r.Primary.ID); err != nil {
+		if _, err := api.NewLocalCNAMEAPI(client).Get(context.Background(), r.Primary.ID); err != nil {
 			if !errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
 				return err
 			}
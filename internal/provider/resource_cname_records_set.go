@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+const (
+	// cnameRecordsSetID is the ID of the single set of all CNAME records of a Pi-hole
+	cnameRecordsSetID = "cname_records"
+)
+
+// resourceCNAMERecordsSet returns the authoritative CNAME records Terraform resource management configuration
+func resourceCNAMERecordsSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages all Pi-hole CNAME records as a single resource. CNAME records which are not configured are removed, so this resource should not be used together with pihole_cname_record. " +
+			"Destroying the resource removes the CNAME records held in its state, including records adopted from Pi-hole, and keeps records added since the last refresh",
+		CreateContext: resourceCNAMERecordsSetCreate,
+		ReadContext:   resourceCNAMERecordsSetRead,
+		UpdateContext: resourceCNAMERecordsSetUpdate,
+		DeleteContext: resourceCNAMERecordsSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCNAMERecordsSetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"record": {
+				Description: "CNAME record",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Description:  "Domain to create a CNAME record for",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"target": {
+							Description:  "Value of the CNAME record where traffic will be directed to from the configured domain value",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"ttl": {
+							Description:  "TTL in seconds of the CNAME record. Pi-hole's default TTL is used when unset",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandCNAMERecordsSet builds the CNAME record list of the resource configuration, sorted by domain
+func expandCNAMERecordsSet(d *schema.ResourceData) (pihole.CNAMERecordList, error) {
+	set := d.Get("record").(*schema.Set).List()
+
+	records := make(pihole.CNAMERecordList, len(set))
+	domains := make(map[string]bool, len(set))
+
+	for i, item := range set {
+		record := item.(map[string]interface{})
+
+		records[i] = pihole.CNAMERecord{
+			Domain: record["domain"].(string),
+			Target: record["target"].(string),
+			TTL:    record["ttl"].(int),
+		}
+
+		domain := strings.ToLower(records[i].Domain)
+		if domains[domain] {
+			return nil, fmt.Errorf("CNAME record domain %s is configured more than once", records[i].Domain)
+		}
+		domains[domain] = true
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Domain < records[j].Domain
+	})
+
+	return records, nil
+}
+
+// setCNAMERecords replaces all CNAME records of all instances
+func setCNAMERecords(ctx context.Context, meta interface{}, records pihole.CNAMERecordList) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	return clients.each("Failed to set CNAME records", func(_ int, client *api.Client) error {
+		_, err := api.NewLocalCNAMEAPI(client).Set(ctx, records)
+		return err
+	})
+}
+
+// resourceCNAMERecordsSetCreate handles the replacement of all CNAME records via Terraform
+func resourceCNAMERecordsSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	records, err := expandCNAMERecordsSet(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := setCNAMERecords(ctx, meta, records); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(cnameRecordsSetID)
+
+	return resourceCNAMERecordsSetRead(ctx, d, meta)
+}
+
+// resourceCNAMERecordsSetRead retrieves all CNAME records, exposing records added outside of Terraform as drift
+func resourceCNAMERecordsSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	sets := make([][]map[string]interface{}, len(clients))
+
+	diags := clients.each("Failed to read CNAME records", func(n int, client *api.Client) error {
+		records, err := api.NewLocalCNAMEAPI(client).List(ctx)
+		if err != nil {
+			return err
+		}
+
+		sets[n] = make([]map[string]interface{}, len(records))
+		for i, record := range records {
+			sets[n][i] = map[string]interface{}{
+				"domain": record.Domain,
+				"target": record.Target,
+				"ttl":    record.TTL,
+			}
+		}
+
+		return nil
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	list, diags := clients.mergeSets(sets, d.Get("record").(*schema.Set), func(record map[string]interface{}) string {
+		return fmt.Sprintf("CNAME record %s %s with TTL %d", record["domain"], record["target"], record["ttl"])
+	})
+
+	if err := d.Set("record", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceCNAMERecordsSetUpdate handles the replacement of all CNAME records in a single write via Terraform
+func resourceCNAMERecordsSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	records, err := expandCNAMERecordsSet(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if diags := setCNAMERecords(ctx, meta, records); diags.HasError() {
+		return diags
+	}
+
+	return resourceCNAMERecordsSetRead(ctx, d, meta)
+}
+
+// resourceCNAMERecordsSetDelete handles the removal of the CNAME records held in state via Terraform
+func resourceCNAMERecordsSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	records, err := expandCNAMERecordsSet(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := clients.each("Failed to remove CNAME records", func(_ int, client *api.Client) error {
+		return api.NewLocalCNAMEAPI(client).Remove(ctx, records)
+	})
+
+	if !diags.HasError() {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+// resourceCNAMERecordsSetImport imports the existing CNAME records regardless of the passed ID
+func resourceCNAMERecordsSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(cnameRecordsSetID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// unmanagedCNAMEDomain is the domain of a CNAME record added outside of Terraform after the last refresh
+const unmanagedCNAMEDomain = "unmanaged.com"
+
+// TestAccCNAMERecordsSet acceptance test for the authoritative CNAME records resource
+func TestAccCNAMERecordsSet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCNAMERecordsSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testCNAMERecordsSetResourceConfig(map[string]string{"foo.com": "bar.com", "baz.com": "bar.com"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_cname_records_set.all", "record.#", "2"),
+					testCheckCNAMERecordsSetCount(2),
+					testCheckLocalCNAMEResourceExists(t, "foo.com", "bar.com"),
+					testCheckLocalCNAMEResourceExists(t, "baz.com", "bar.com"),
+				),
+			},
+			{
+				Config: testCNAMERecordsSetResourceConfig(map[string]string{"foo.com": "woz.com"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_cname_records_set.all", "record.#", "1"),
+					testCheckCNAMERecordsSetCount(1),
+					testCheckLocalCNAMEResourceExists(t, "foo.com", "woz.com"),
+				),
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(instances)[0]
+
+					if _, err := api.NewLocalCNAMEAPI(client).Create(context.Background(), "drift.com", "woz.com", 0); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testCNAMERecordsSetResourceConfig(map[string]string{"foo.com": "woz.com"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testCNAMERecordsSetResourceConfig(map[string]string{"foo.com": "woz.com"}),
+				Check:  testCheckCNAMERecordsSetCount(1),
+			},
+			{
+				ResourceName:      "pihole_cname_records_set.all",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// The post-test destroy does not refresh, so a CNAME record added now is not held in state and must survive the destroy
+				PreConfig: func() {
+					client := testAccProvider.Meta().(instances)[0]
+
+					if _, err := api.NewLocalCNAMEAPI(client).Create(context.Background(), unmanagedCNAMEDomain, "woz.com", 0); err != nil {
+						t.Fatal(err)
+					}
+				},
+				ResourceName: "pihole_cname_records_set.all",
+				ImportState:  true,
+			},
+		},
+	})
+}
+
+// testCNAMERecordsSetResourceConfig returns HCL to configure all CNAME records by domain
+func testCNAMERecordsSetResourceConfig(records map[string]string) string {
+	blocks := make([]string, 0, len(records))
+
+	for domain, target := range records {
+		blocks = append(blocks, fmt.Sprintf(`
+			record {
+				domain = %q
+				target = %q
+			}`, domain, target))
+	}
+
+	return fmt.Sprintf(`
+		resource "pihole_cname_records_set" "all" {%s
+		}
+	`, strings.Join(blocks, ""))
+}
+
+// testCheckCNAMERecordsSetCount checks the number of CNAME records in Pi-hole
+func testCheckCNAMERecordsSetCount(count int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(instances)[0]
+
+		records, err := api.NewLocalCNAMEAPI(client).List(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if len(records) != count {
+			return fmt.Errorf("expected %d CNAME records, found %d", count, len(records))
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckCNAMERecordsSetDestroy checks that the CNAME records held in state have been removed and that the unmanaged CNAME record was kept
+func testAccCheckCNAMERecordsSetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(instances)[0]
+	cnameAPI := api.NewLocalCNAMEAPI(client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_cname_records_set" {
+			continue
+		}
+
+		for key, domain := range r.Primary.Attributes {
+			if !strings.HasPrefix(key, "record.") || !strings.HasSuffix(key, ".domain") {
+				continue
+			}
+
+			_, err := cnameAPI.Get(context.Background(), domain)
+			if err == nil {
+				return fmt.Errorf("CNAME record %s still exists", domain)
+			}
+
+			if !errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
+				return err
+			}
+		}
+
+		if _, err := cnameAPI.Get(context.Background(), unmanagedCNAMEDomain); err != nil {
+			return fmt.Errorf("expected CNAME record %s which is not held in state to be kept: %w", unmanagedCNAMEDomain, err)
+		}
+
+		return cnameAPI.Delete(context.Background(), unmanagedCNAMEDomain)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceConfig returns the generic config value Terraform resource management configuration
+func resourceConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a single Pi-hole config value by its dotted key, for settings without a dedicated resource. " +
+			"The previous value is restored when the resource is destroyed",
+		CreateContext: resourceConfigCreate,
+		ReadContext:   resourceConfigRead,
+		UpdateContext: resourceConfigUpdate,
+		DeleteContext: resourceConfigDelete,
+		CustomizeDiff: resourceConfigCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConfigImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Description: "Dotted config key, e.g. dns.queryLogging. Keys managed by other resources, dns.hosts, dns.cnameRecords, dns.upstreams and dns.revServers, are not allowed",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: validation.All(
+					validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)+$`), "expected a dotted config key, e.g. dns.queryLogging"),
+					validation.StringNotInSlice([]string{"dns.hosts", "dns.cnameRecords", "dns.upstreams", "dns.revServers"}, false),
+				),
+			},
+			"value": {
+				Description:      "JSON encoded value, e.g. jsonencode(true). The JSON type must match the type of the config value",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: suppressJSONDiff,
+			},
+			"previous_value": {
+				Description: "JSON encoded value before the resource was created or imported, restored when the resource is destroyed",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// configPath converts a dotted config key into the slash separated path of the config API
+func configPath(key string) string {
+	return strings.ReplaceAll(key, ".", "/")
+}
+
+// sameJSON reports whether two JSON documents encode the same value
+func sameJSON(a string, b string) bool {
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
+
+// suppressJSONDiff suppresses differences in JSON formatting
+func suppressJSONDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return sameJSON(old, new)
+}
+
+// configJSONType returns the JSON type expected for a config value of the passed server type
+func configJSONType(configType string) string {
+	configType = strings.ToLower(configType)
+
+	switch {
+	case strings.Contains(configType, "bool"):
+		return "boolean"
+	case strings.Contains(configType, "array"):
+		return "array"
+	case strings.Contains(configType, "integer"), strings.Contains(configType, "double"), strings.Contains(configType, "float"):
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// jsonType returns the JSON type of a decoded value
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case nil:
+		return "null"
+	default:
+		return "object"
+	}
+}
+
+// errInvalidConfigValue wraps errors of config keys and values which do not match the server's config schema
+var errInvalidConfigValue = errors.New("invalid config value")
+
+// validateConfigValue validates a config key and its JSON encoded value against the server's config schema,
+// returning the decoded value
+func validateConfigValue(ctx context.Context, client *api.Client, key string, value string) (interface{}, error) {
+	details, err := client.GetConfigDetails(ctx, configPath(key))
+	if err != nil {
+		switch {
+		case errors.Is(err, api.ErrorConfigNotFound):
+			return nil, fmt.Errorf("%w: unknown config key %s", errInvalidConfigValue, key)
+		case errors.Is(err, api.ErrorConfigNotValue):
+			return nil, fmt.Errorf("%w: config key %s is a group of config values, expected the key of a single value", errInvalidConfigValue, key)
+		default:
+			return nil, err
+		}
+	}
+
+	if strings.Contains(strings.ToLower(details.Type), "write-only") {
+		return nil, fmt.Errorf("%w: config key %s is write-only and cannot be managed", errInvalidConfigValue, key)
+	}
+
+	if details.Flags.EnvVar {
+		return nil, fmt.Errorf("%w: config key %s is set by an environment variable of the Pi-hole process and cannot be changed", errInvalidConfigValue, key)
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+		return nil, fmt.Errorf("%w: config key %s: %w", errInvalidConfigValue, key, err)
+	}
+
+	if expected := configJSONType(details.Type); jsonType(decoded) != expected {
+		return nil, fmt.Errorf("%w: config key %s is of type %s and expects a JSON %s, got %s", errInvalidConfigValue, key, details.Type, expected, value)
+	}
+
+	return decoded, nil
+}
+
+// resourceConfigCustomizeDiff validates changed config values against the server's config schema during planning
+func resourceConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("value") {
+		return nil
+	}
+
+	if !d.NewValueKnown("key") || !d.NewValueKnown("value") {
+		return nil
+	}
+
+	client, ok := metaClient(meta)
+	if !ok {
+		return nil
+	}
+
+	// Pi-hole may not be reachable yet, e.g. when it is created in the same run, values are validated again on apply
+	key := d.Get("key").(string)
+	if _, err := validateConfigValue(ctx, client, key, d.Get("value").(string)); err != nil {
+		if errors.Is(err, errInvalidConfigValue) {
+			return err
+		}
+
+		log.Printf("[WARN] Skipping validation of config key %s: %s", key, err)
+	}
+
+	return nil
+}
+
+// resourceConfigCreate handles setting a config value via Terraform, keeping the previous value
+func resourceConfigCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	key := d.Get("key").(string)
+
+	value, err := validateConfigValue(ctx, client, key, d.Get("value").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The previous value is read rather than taken from the cached detailed config, whose values may be outdated
+	current, err := client.GetConfig(ctx, configPath(key))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	previous, err := json.Marshal(current)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.PatchConfig(ctx, configPath(key), value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(key)
+
+	if err := d.Set("previous_value", string(previous)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceConfigRead(ctx, d, meta)
+}
+
+// resourceConfigRead retrieves the current config value of the key ID
+func resourceConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	value, err := client.GetConfig(ctx, configPath(d.Id()))
+	if err != nil {
+		if errors.Is(err, api.ErrorConfigNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("key", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !sameJSON(d.Get("value").(string), string(encoded)) {
+		if err := d.Set("value", string(encoded)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+// resourceConfigUpdate handles changes of a config value via Terraform
+func resourceConfigUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	value, err := validateConfigValue(ctx, client, d.Id(), d.Get("value").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.PatchConfig(ctx, configPath(d.Id()), value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceConfigRead(ctx, d, meta)
+}
+
+// resourceConfigDelete restores the previous config value via Terraform
+func resourceConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if previous := d.Get("previous_value").(string); previous != "" {
+		var value interface{}
+		if err := json.Unmarshal([]byte(previous), &value); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := client.PatchConfig(ctx, configPath(d.Id()), value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceConfigImport imports a config value by its dotted key, keeping the current value to be restored on destroy
+func resourceConfigImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return nil, errors.New("could not load client in resource request")
+	}
+
+	value, err := client.GetConfig(ctx, configPath(d.Id()))
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("previous_value", string(encoded)); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
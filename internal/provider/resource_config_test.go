@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccConfig acceptance test for the generic config value resource
+func TestAccConfig(t *testing.T) {
+	var previous interface{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			return testCheckConfigValue("dns.queryLogging", previous)(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					var err error
+					if previous, err = testAccProvider.Meta().(instances)[0].GetConfig(context.Background(), "dns/queryLogging"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testConfigResourceConfig("dns.queryLogging", "jsonencode(false)"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config.config", "value", "false"),
+					testCheckConfigValue("dns.queryLogging", false),
+				),
+			},
+			{
+				Config: testConfigResourceConfig("dns.queryLogging", "jsonencode(true)"),
+				Check:  testCheckConfigValue("dns.queryLogging", true),
+			},
+			{
+				ResourceName:            "pihole_config.config",
+				ImportState:             true,
+				ImportStateId:           "dns.queryLogging",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"previous_value"},
+			},
+			{
+				Config:      testConfigResourceConfig("dns.queryLogging", `jsonencode("yes")`),
+				ExpectError: regexp.MustCompile("expects a JSON boolean"),
+			},
+			{
+				Config:      testConfigResourceConfig("dns.unknownKey", "jsonencode(true)"),
+				ExpectError: regexp.MustCompile("unknown config key"),
+			},
+			{
+				Config:      testConfigResourceConfig("dns.reply", "jsonencode(true)"),
+				ExpectError: regexp.MustCompile("is a group of config values"),
+			},
+		},
+	})
+}
+
+// testConfigResourceConfig returns HCL to configure a config value
+func testConfigResourceConfig(key string, value string) string {
+	return fmt.Sprintf(`
+		resource "pihole_config" "config" {
+			key   = %q
+			value = %s
+		}
+	`, key, value)
+}
+
+// testCheckConfigValue checks a config value of Pi-hole
+func testCheckConfigValue(key string, expected interface{}) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		value, err := testAccProvider.Meta().(instances)[0].GetConfig(context.Background(), configPath(key))
+		if err != nil {
+			return err
+		}
+
+		if value != expected {
+			return fmt.Errorf("expected config %s to be %v, got %v", key, expected, value)
+		}
+
+		return nil
+	}
+}
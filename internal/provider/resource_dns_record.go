@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
 // resourceDNSRecord returns the local DNS Terraform resource management configuration
@@ -15,9 +18,23 @@ func resourceDNSRecord() *schema.Resource {
 		Description:   "Manages a Pi-hole DNS record",
 		CreateContext: resourceDNSRecordCreate,
 		ReadContext:   resourceDNSRecordRead,
+		UpdateContext: resourceDNSRecordUpdate,
 		DeleteContext: resourceDNSRecordDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDNSRecordImport,
+		},
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceDNSRecordV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDNSRecordStateUpgradeV0,
+			},
+			{
+				Version: 1,
+				Type:    resourceDNSRecordV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceDNSRecordStateUpgradeV1,
+			},
 		},
 		Schema: map[string]*schema.Schema{
 			"domain": {
@@ -30,15 +47,91 @@ func resourceDNSRecord() *schema.Resource {
 				Description: "IP address to route traffic to from the DNS record domain",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 		},
 	}
 }
 
+// resourceDNSRecordV0 returns the version 0 schema of the local DNS resource, which version 1 shares
+func resourceDNSRecordV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ip": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+// resourceDNSRecordStateUpgradeV0 migrates domain IDs to domain,ip IDs
+func resourceDNSRecordStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	id, _ := rawState["id"].(string)
+	if strings.Contains(id, ",") {
+		return rawState, nil
+	}
+
+	domain, _ := rawState["domain"].(string)
+	ip, _ := rawState["ip"].(string)
+
+	if domain == "" {
+		domain = id
+	}
+
+	rawState["id"] = fmt.Sprintf("%s,%s", domain, ip)
+
+	return rawState, nil
+}
+
+// resourceDNSRecordStateUpgradeV1 migrates the domain,ip IDs of version 1 to domain IDs, as the IP is held in state
+func resourceDNSRecordStateUpgradeV1(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	id, _ := rawState["id"].(string)
+
+	domain, ip, err := parseDNSRecordImportID(id)
+	if err != nil {
+		return rawState, nil
+	}
+
+	rawState["id"] = domain
+
+	if stateIP, _ := rawState["ip"].(string); stateIP == "" {
+		rawState["ip"] = ip
+	}
+
+	return rawState, nil
+}
+
+// parseDNSRecordImportID splits a domain,ip import ID of a local DNS record into its domain and IP
+func parseDNSRecordImportID(id string) (string, string, error) {
+	domain, ip, ok := strings.Cut(id, ",")
+	if !ok || domain == "" || ip == "" {
+		return "", "", fmt.Errorf("unexpected DNS record ID %q, expected domain,ip", id)
+	}
+
+	return domain, ip, nil
+}
+
+// dnsRecordKey returns the domain and IP a local DNS record is located by. The domain ID does not hold the IP,
+// which can be updated in place, so the record is located by the IP held in state.
+func dnsRecordKey(d *schema.ResourceData) (string, string) {
+	return d.Id(), d.Get("ip").(string)
+}
+
 // resourceDNSRecordCreate handles the creation a local DNS record via Terraform
-func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
@@ -46,56 +139,185 @@ func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta i
 	domain := d.Get("domain").(string)
 	ip := d.Get("ip").(string)
 
-	_, err := client.LocalDNS.Create(ctx, domain, ip)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	diags := clients.each("Failed to create DNS record", func(_ int, client *api.Client) error {
+		dnsAPI := api.NewLocalDNSAPI(client)
+
+		// Records already present on some instances of a redundant setup are adopted
+		if clients.multiple() {
+			if _, err := dnsAPI.Get(ctx, domain, ip); err == nil {
+				return nil
+			} else if !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
+				return err
+			}
+		}
+
+		_, err := dnsAPI.Create(ctx, domain, ip)
+		return err
+	})
 
-	d.SetId(domain)
+	if !diags.HasError() {
+		d.SetId(domain)
+	}
 
 	return diags
 }
 
-// resourceDNSRecordRead finds a local DNS record based on the associated domain ID
-func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+// resourceDNSRecordRead finds a local DNS record based on its domain ID and the IP held in state
+func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	record, err := client.LocalDNS.Get(ctx, d.Id())
-	if err != nil {
+	domain, ip := dnsRecordKey(d)
+
+	records := make([]*pihole.DNSRecord, len(clients))
+
+	diags := clients.each("Failed to read DNS record", func(n int, client *api.Client) error {
+		record, err := api.NewLocalDNSAPI(client).Get(ctx, domain, ip)
 		if errors.Is(err, pihole.ErrorLocalDNSNotFound) {
-			d.SetId("")
 			return nil
 		}
 
-		return diag.FromErr(err)
+		records[n] = record
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
 	}
 
-	if err = d.Set("domain", record.Domain); err != nil {
-		return diag.FromErr(err)
+	var found *pihole.DNSRecord
+	missing := false
+	for n, record := range records {
+		if record == nil {
+			missing = true
+			if clients.multiple() {
+				diags = append(diags, driftWarning(clients[n], "DNS record %s %s is missing and will be created", domain, ip))
+			}
+			continue
+		}
+
+		if found == nil {
+			found = record
+		}
 	}
 
-	if err = d.Set("ip", record.IP); err != nil {
+	// A record missing on any instance is removed from state, so the next apply creates it again
+	if missing {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("domain", found.Domain); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if !api.SameIP(ip, found.IP) {
+		if err := d.Set("ip", found.IP); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return diags
 }
 
-// resourceDNSRecordDelete handles the deletion of a local DNS record via Terraform
-func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
-	client, ok := meta.(*pihole.Client)
+// resourceDNSRecordUpdate handles in place IP changes of a local DNS record, swapping the IP in a single write
+func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
 	if !ok {
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	if err := client.LocalDNS.Delete(ctx, d.Id()); err != nil {
-		return diag.FromErr(err)
+	domain := d.Id()
+
+	// The record is located by the IP held in state before the update
+	o, n := d.GetChange("ip")
+	ip, newIP := o.(string), n.(string)
+
+	diags := clients.each("Failed to update DNS record", func(_ int, client *api.Client) error {
+		dnsAPI := api.NewLocalDNSAPI(client)
+
+		_, err := dnsAPI.Update(ctx, domain, ip, newIP)
+		if errors.Is(err, pihole.ErrorLocalDNSNotFound) && clients.multiple() {
+			_, err = dnsAPI.Create(ctx, domain, newIP)
+		}
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceDNSRecordRead(ctx, d, meta)
+}
+
+// resourceDNSRecordDelete handles the deletion of a local DNS record via Terraform
+func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
 	}
 
-	d.SetId("")
+	domain, ip := dnsRecordKey(d)
+
+	diags := clients.each("Failed to delete DNS record", func(_ int, client *api.Client) error {
+		return api.NewLocalDNSAPI(client).Delete(ctx, domain, ip)
+	})
+
+	if !diags.HasError() {
+		d.SetId("")
+	}
 
 	return diags
 }
+
+// resourceDNSRecordImport imports a local DNS record by a domain,ip ID, or by its domain when it has a single IP
+func resourceDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.Contains(d.Id(), ",") {
+		domain, ip, err := parseDNSRecordImportID(d.Id())
+		if err != nil {
+			return nil, err
+		}
+
+		d.SetId(domain)
+
+		if err := d.Set("ip", ip); err != nil {
+			return nil, err
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client, ok := metaClient(meta)
+	if !ok {
+		return nil, errors.New("could not load client in resource request")
+	}
+
+	records, err := api.NewLocalDNSAPI(client).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []pihole.DNSRecord
+	for _, record := range records {
+		if strings.EqualFold(record.Domain, d.Id()) {
+			matches = append(matches, record)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", pihole.ErrorLocalDNSNotFound, d.Id())
+	case 1:
+		d.SetId(matches[0].Domain)
+
+		if err := d.Set("ip", matches[0].IP); err != nil {
+			return nil, err
+		}
+
+		return []*schema.ResourceData{d}, nil
+	default:
+		return nil, fmt.Errorf("domain %s has %d DNS records, import one of them by its domain,ip ID", d.Id(), len(matches))
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
 )
 
 func TestAccLocalDNS(t *testing.T) {
@@ -28,11 +30,28 @@ func TestAccLocalDNS(t *testing.T) {
 			{
 				Config: testLocalDNSResourceConfig("foo", "foo.com", "127.0.0.2"),
 				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "id", "foo.com"),
 					resource.TestCheckResourceAttr("pihole_dns_record.foo", "domain", "foo.com"),
 					resource.TestCheckResourceAttr("pihole_dns_record.foo", "ip", "127.0.0.2"),
 					testCheckLocalDNSResourceExists(t, "foo.com", "127.0.0.2"),
 				),
 			},
+			{
+				Config: testLocalDNSResourceConfig("foo", "foo.com", "127.0.0.2") + testLocalDNSResourceConfig("foo_v6", "foo.com", "fd00::2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_record.foo", "ip", "127.0.0.2"),
+					resource.TestCheckResourceAttr("pihole_dns_record.foo_v6", "id", "foo.com"),
+					resource.TestCheckResourceAttr("pihole_dns_record.foo_v6", "ip", "fd00::2"),
+					testCheckLocalDNSResourceExists(t, "foo.com", "127.0.0.2"),
+					testCheckLocalDNSResourceExists(t, "foo.com", "fd00::2"),
+				),
+			},
+			{
+				ResourceName:      "pihole_dns_record.foo_v6",
+				ImportState:       true,
+				ImportStateId:     "foo.com,fd00::2",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -48,30 +67,25 @@ func testLocalDNSResourceConfig(name string, domain string, ip string) string {
 
 func testCheckLocalDNSResourceExists(_ *testing.T, domain string, ip string) resource.TestCheckFunc {
 	return func(*terraform.State) error {
-		client := testAccProvider.Meta().(*pihole.Client)
+		client := testAccProvider.Meta().(instances)[0]
 
-		record, err := client.LocalDNS.Get(context.Background(), domain)
-		if err != nil {
+		if _, err := api.NewLocalDNSAPI(client).Get(context.Background(), domain, ip); err != nil {
 			return err
 		}
 
-		if record.IP != ip {
-			return fmt.Errorf("requested %s:%s does not match IP: %s", domain, ip, record.IP)
-		}
-
 		return nil
 	}
 }
 
 func testAccCheckLocalDNSDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*pihole.Client)
+	client := testAccProvider.Meta().(instances)[0]
 
 	for _, r := range s.RootModule().Resources {
 		if r.Type != "pihole_dns_record" {
 			continue
 		}
 
-		if _, err := client.LocalDNS.Get(context.Background(), r.Primary.ID); err != nil {
+		if _, err := api.NewLocalDNSAPI(client).Get(context.Background(), r.Primary.ID, r.Primary.Attributes["ip"]); err != nil {
 			if !errors.Is(err, pihole.ErrorLocalDNSNotFound) {
 				return err
 			}
@@ -80,3 +94,61 @@ func testAccCheckLocalDNSDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func TestResourceDNSRecordStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		state    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			state:    map[string]interface{}{"id": "foo.com", "domain": "foo.com", "ip": "127.0.0.1"},
+			expected: map[string]interface{}{"id": "foo.com,127.0.0.1", "domain": "foo.com", "ip": "127.0.0.1"},
+		},
+		{
+			state:    map[string]interface{}{"id": "foo.com,fd00::1", "domain": "foo.com", "ip": "fd00::1"},
+			expected: map[string]interface{}{"id": "foo.com,fd00::1", "domain": "foo.com", "ip": "fd00::1"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := resourceDNSRecordStateUpgradeV0(context.Background(), c.state, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, actual)
+		}
+	}
+}
+
+func TestResourceDNSRecordStateUpgradeV1(t *testing.T) {
+	cases := []struct {
+		state    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			state:    map[string]interface{}{"id": "foo.com,127.0.0.1", "domain": "foo.com", "ip": "127.0.0.2"},
+			expected: map[string]interface{}{"id": "foo.com", "domain": "foo.com", "ip": "127.0.0.2"},
+		},
+		{
+			state:    map[string]interface{}{"id": "foo.com,fd00::1", "domain": "foo.com"},
+			expected: map[string]interface{}{"id": "foo.com", "domain": "foo.com", "ip": "fd00::1"},
+		},
+		{
+			state:    map[string]interface{}{"id": "foo.com", "domain": "foo.com", "ip": "127.0.0.1"},
+			expected: map[string]interface{}{"id": "foo.com", "domain": "foo.com", "ip": "127.0.0.1"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := resourceDNSRecordStateUpgradeV1(context.Background(), c.state, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Fatalf("expected %v, got %v", c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+const (
+	// dnsRecordsSetID is the ID of the single set of all DNS records of a Pi-hole
+	dnsRecordsSetID = "dns_records"
+)
+
+// resourceDNSRecordsSet returns the authoritative local DNS records Terraform resource management configuration
+func resourceDNSRecordsSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages all Pi-hole DNS records as a single resource. DNS records which are not configured are removed, so this resource should not be used together with pihole_dns_record. " +
+			"Destroying the resource removes the DNS records held in its state, including records adopted from Pi-hole, and keeps records added since the last refresh",
+		CreateContext: resourceDNSRecordsSetCreate,
+		ReadContext:   resourceDNSRecordsSetRead,
+		UpdateContext: resourceDNSRecordsSetUpdate,
+		DeleteContext: resourceDNSRecordsSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSRecordsSetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"record": {
+				Description: "DNS record",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Description:  "DNS record domain",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+						"ip": {
+							Description:  "IP address to route traffic to from the DNS record domain",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsIPAddress,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandDNSRecordsSet builds the DNS record list of the resource configuration, sorted by domain and IP
+func expandDNSRecordsSet(d *schema.ResourceData) pihole.DNSRecordList {
+	set := d.Get("record").(*schema.Set).List()
+
+	records := make(pihole.DNSRecordList, len(set))
+	for i, item := range set {
+		record := item.(map[string]interface{})
+
+		records[i] = pihole.DNSRecord{
+			Domain: record["domain"].(string),
+			IP:     record["ip"].(string),
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Domain != records[j].Domain {
+			return records[i].Domain < records[j].Domain
+		}
+
+		return records[i].IP < records[j].IP
+	})
+
+	return records
+}
+
+// setDNSRecords replaces all DNS records of all instances
+func setDNSRecords(ctx context.Context, meta interface{}, records pihole.DNSRecordList) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	return clients.each("Failed to set DNS records", func(_ int, client *api.Client) error {
+		_, err := api.NewLocalDNSAPI(client).Set(ctx, records)
+		return err
+	})
+}
+
+// resourceDNSRecordsSetCreate handles the replacement of all DNS records via Terraform
+func resourceDNSRecordsSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := setDNSRecords(ctx, meta, expandDNSRecordsSet(d)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(dnsRecordsSetID)
+
+	return resourceDNSRecordsSetRead(ctx, d, meta)
+}
+
+// resourceDNSRecordsSetRead retrieves all DNS records, exposing records added outside of Terraform as drift
+func resourceDNSRecordsSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	prior := expandDNSRecordsSet(d)
+	sets := make([][]map[string]interface{}, len(clients))
+
+	diags := clients.each("Failed to read DNS records", func(n int, client *api.Client) error {
+		records, err := api.NewLocalDNSAPI(client).List(ctx)
+		if err != nil {
+			return err
+		}
+
+		sets[n] = make([]map[string]interface{}, len(records))
+		for i, record := range records {
+			sets[n][i] = map[string]interface{}{
+				"domain": record.Domain,
+				"ip":     priorIP(prior, record),
+			}
+		}
+
+		return nil
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	list, diags := clients.mergeSets(sets, d.Get("record").(*schema.Set), func(record map[string]interface{}) string {
+		return fmt.Sprintf("DNS record %s %s", record["domain"], record["ip"])
+	})
+
+	if err := d.Set("record", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// priorIP returns the IP of a DNS record in the notation of the matching record in state, so IPv6 addresses
+// written differently by Pi-hole do not show up as a change
+func priorIP(prior pihole.DNSRecordList, record pihole.DNSRecord) string {
+	for _, p := range prior {
+		if p.Domain == record.Domain && api.SameIP(p.IP, record.IP) {
+			return p.IP
+		}
+	}
+
+	return record.IP
+}
+
+// resourceDNSRecordsSetUpdate handles the replacement of all DNS records in a single write via Terraform
+func resourceDNSRecordsSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := setDNSRecords(ctx, meta, expandDNSRecordsSet(d)); diags.HasError() {
+		return diags
+	}
+
+	return resourceDNSRecordsSetRead(ctx, d, meta)
+}
+
+// resourceDNSRecordsSetDelete handles the removal of the DNS records held in state via Terraform
+func resourceDNSRecordsSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	records := expandDNSRecordsSet(d)
+
+	diags := clients.each("Failed to remove DNS records", func(_ int, client *api.Client) error {
+		return api.NewLocalDNSAPI(client).Remove(ctx, records)
+	})
+
+	if !diags.HasError() {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+// resourceDNSRecordsSetImport imports the existing DNS records regardless of the passed ID
+func resourceDNSRecordsSetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(dnsRecordsSetID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccDNSRecordsSet acceptance test for the authoritative DNS records resource
+func TestAccDNSRecordsSet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDNSRecordsSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDNSRecordsSetResourceConfig(map[string]string{"foo.com": "127.0.0.1", "bar.com": "127.0.0.2"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_records_set.all", "record.#", "2"),
+					testCheckDNSRecordsSetCount(2),
+					testCheckLocalDNSResourceExists(t, "foo.com", "127.0.0.1"),
+					testCheckLocalDNSResourceExists(t, "bar.com", "127.0.0.2"),
+				),
+			},
+			{
+				Config: testDNSRecordsSetResourceConfig(map[string]string{"foo.com": "127.0.0.3"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_records_set.all", "record.#", "1"),
+					testCheckDNSRecordsSetCount(1),
+					testCheckLocalDNSResourceExists(t, "foo.com", "127.0.0.3"),
+				),
+			},
+			{
+				PreConfig: func() {
+					client := testAccProvider.Meta().(instances)[0]
+
+					if _, err := api.NewLocalDNSAPI(client).Create(context.Background(), "drift.com", "127.0.0.4"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testDNSRecordsSetResourceConfig(map[string]string{"foo.com": "127.0.0.3"}),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testDNSRecordsSetResourceConfig(map[string]string{"foo.com": "127.0.0.3"}),
+				Check:  testCheckDNSRecordsSetCount(1),
+			},
+			{
+				ResourceName:      "pihole_dns_records_set.all",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testDNSRecordsSetResourceConfig returns HCL to configure all DNS records by domain
+func testDNSRecordsSetResourceConfig(records map[string]string) string {
+	blocks := make([]string, 0, len(records))
+
+	for domain, ip := range records {
+		blocks = append(blocks, fmt.Sprintf(`
+			record {
+				domain = %q
+				ip     = %q
+			}`, domain, ip))
+	}
+
+	return fmt.Sprintf(`
+		resource "pihole_dns_records_set" "all" {%s
+		}
+	`, strings.Join(blocks, ""))
+}
+
+// testCheckDNSRecordsSetCount checks the number of DNS records in Pi-hole
+func testCheckDNSRecordsSetCount(count int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(instances)[0]
+
+		records, err := api.NewLocalDNSAPI(client).List(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if len(records) != count {
+			return fmt.Errorf("expected %d DNS records, found %d", count, len(records))
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckDNSRecordsSetDestroy checks that all DNS records have been removed
+func testAccCheckDNSRecordsSetDestroy(s *terraform.State) error {
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_dns_records_set" {
+			continue
+		}
+
+		return testCheckDNSRecordsSetCount(0)(s)
+	}
+
+	return nil
+}
+
+func TestPriorIP(t *testing.T) {
+	prior := pihole.DNSRecordList{{Domain: "foo.com", IP: "2001:db8::1"}}
+
+	if ip := priorIP(prior, pihole.DNSRecord{Domain: "foo.com", IP: "2001:0db8:0:0::1"}); ip != "2001:db8::1" {
+		t.Fatalf("expected the IP notation of the state, got %s", ip)
+	}
+
+	if ip := priorIP(prior, pihole.DNSRecord{Domain: "foo.com", IP: "2001:db8::2"}); ip != "2001:db8::2" {
+		t.Fatalf("expected a different IP to be kept, got %s", ip)
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+const (
+	// dnsUpstreamsID is the ID of the single list of upstream DNS servers of a Pi-hole
+	dnsUpstreamsID = "dns_upstreams"
+)
+
+// resourceDNSUpstreams returns the upstream DNS servers Terraform resource management configuration
+func resourceDNSUpstreams() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the upstream DNS servers Pi-hole forwards queries to, in the order they are configured. " +
+			"The upstream DNS servers are left unchanged when the resource is destroyed",
+		CreateContext: resourceDNSUpstreamsCreate,
+		ReadContext:   resourceDNSUpstreamsRead,
+		UpdateContext: resourceDNSUpstreamsUpdate,
+		DeleteContext: resourceDNSUpstreamsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDNSUpstreamsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"upstreams": {
+				Description: "Upstream DNS servers in ip or ip#port format, e.g. 1.1.1.1 or 127.0.0.1#5335",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateUpstream,
+				},
+			},
+		},
+	}
+}
+
+// validateUpstream validates an upstream DNS server in ip or ip#port format
+func validateUpstream(v interface{}, _ string) (ws []string, es []error) {
+	if err := api.ValidateUpstream(v.(string)); err != nil {
+		es = append(es, err)
+	}
+
+	return ws, es
+}
+
+// expandDNSUpstreams returns the configured upstream DNS servers
+func expandDNSUpstreams(d *schema.ResourceData) []string {
+	list := d.Get("upstreams").([]interface{})
+
+	upstreams := make([]string, len(list))
+	for i, upstream := range list {
+		upstreams[i] = upstream.(string)
+	}
+
+	return upstreams
+}
+
+// setDNSUpstreams replaces the upstream DNS servers of all instances
+func setDNSUpstreams(ctx context.Context, meta interface{}, upstreams []string) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	return clients.each("Failed to set upstream DNS servers", func(_ int, client *api.Client) error {
+		_, err := api.NewUpstreamsAPI(client).Set(ctx, upstreams)
+		return err
+	})
+}
+
+// resourceDNSUpstreamsCreate handles setting the upstream DNS servers via Terraform
+func resourceDNSUpstreamsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := setDNSUpstreams(ctx, meta, expandDNSUpstreams(d)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(dnsUpstreamsID)
+
+	return resourceDNSUpstreamsRead(ctx, d, meta)
+}
+
+// resourceDNSUpstreamsRead retrieves the upstream DNS servers, exposing changes made outside of Terraform as drift
+func resourceDNSUpstreamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	lists := make([][]string, len(clients))
+
+	diags := clients.each("Failed to read upstream DNS servers", func(n int, client *api.Client) error {
+		upstreams, err := api.NewUpstreamsAPI(client).Get(ctx)
+		lists[n] = upstreams
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	// Lists are compared to the state, or to the first instance's list after an import. A differing
+	// list on any instance is written to state so the next apply updates it.
+	expected := expandDNSUpstreams(d)
+	if len(expected) == 0 {
+		expected = lists[0]
+	}
+
+	found := lists[0]
+	for n, upstreams := range lists {
+		if !slices.Equal(upstreams, expected) {
+			if clients.multiple() {
+				diags = append(diags, driftWarning(clients[n], "Upstream DNS servers are %s", strings.Join(upstreams, ", ")))
+			}
+
+			found = upstreams
+		}
+	}
+
+	if err := d.Set("upstreams", found); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceDNSUpstreamsUpdate handles changes of the upstream DNS servers in a single write via Terraform
+func resourceDNSUpstreamsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := setDNSUpstreams(ctx, meta, expandDNSUpstreams(d)); diags.HasError() {
+		return diags
+	}
+
+	return resourceDNSUpstreamsRead(ctx, d, meta)
+}
+
+// resourceDNSUpstreamsDelete removes the upstream DNS servers from the state, leaving them unchanged so Pi-hole keeps resolving
+func resourceDNSUpstreamsDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// resourceDNSUpstreamsImport imports the existing upstream DNS servers regardless of the passed ID
+func resourceDNSUpstreamsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(dnsUpstreamsID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccDNSUpstreams acceptance test for the upstream DNS servers resource
+func TestAccDNSUpstreams(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		// Destroying the resource leaves the upstream DNS servers unchanged
+		CheckDestroy: testCheckDNSUpstreams("1.1.1.1#53", "9.9.9.9"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testDNSUpstreamsResourceConfig("1.1.1.1:53"),
+				ExpectError: regexp.MustCompile("invalid upstream"),
+			},
+			{
+				PreConfig: func() {
+					upstreamsAPI := api.NewUpstreamsAPI(testAccProvider.Meta().(instances)[0])
+
+					previous, err := upstreamsAPI.Get(context.Background())
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					t.Cleanup(func() {
+						if _, err := upstreamsAPI.Set(context.Background(), previous); err != nil {
+							t.Error(err)
+						}
+					})
+				},
+				Config: testDNSUpstreamsResourceConfig("9.9.9.9", "1.1.1.1#53"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dns_upstreams.upstreams", "upstreams.#", "2"),
+					resource.TestCheckResourceAttr("pihole_dns_upstreams.upstreams", "upstreams.0", "9.9.9.9"),
+					testCheckDNSUpstreams("9.9.9.9", "1.1.1.1#53"),
+				),
+			},
+			{
+				Config: testDNSUpstreamsResourceConfig("1.1.1.1#53", "9.9.9.9"),
+				Check:  testCheckDNSUpstreams("1.1.1.1#53", "9.9.9.9"),
+			},
+			{
+				PreConfig: func() {
+					if _, err := api.NewUpstreamsAPI(testAccProvider.Meta().(instances)[0]).Set(context.Background(), []string{"8.8.8.8"}); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testDNSUpstreamsResourceConfig("1.1.1.1#53", "9.9.9.9"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testDNSUpstreamsResourceConfig("1.1.1.1#53", "9.9.9.9"),
+				Check:  testCheckDNSUpstreams("1.1.1.1#53", "9.9.9.9"),
+			},
+			{
+				ResourceName:      "pihole_dns_upstreams.upstreams",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testDNSUpstreamsResourceConfig returns HCL to configure the upstream DNS servers
+func testDNSUpstreamsResourceConfig(upstreams ...string) string {
+	return fmt.Sprintf(`
+		resource "pihole_dns_upstreams" "upstreams" {
+			upstreams = ["%s"]
+		}
+	`, strings.Join(upstreams, `", "`))
+}
+
+// testCheckDNSUpstreams checks the upstream DNS servers of Pi-hole
+func testCheckDNSUpstreams(expected ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		upstreams, err := api.NewUpstreamsAPI(testAccProvider.Meta().(instances)[0]).Get(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if !slices.Equal(upstreams, expected) {
+			return fmt.Errorf("expected upstreams %v, got %v", expected, upstreams)
+		}
+
+		return nil
+	}
+}
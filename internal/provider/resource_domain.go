@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceDomain returns the allow/deny list domain Terraform resource management configuration
+func resourceDomain() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole allowed or denied domain",
+		CreateContext: resourceDomainCreate,
+		ReadContext:   resourceDomainRead,
+		UpdateContext: resourceDomainUpdate,
+		DeleteContext: resourceDomainDelete,
+		CustomizeDiff: resourceDomainCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDomainImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name, or regular expression when kind is regex",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Description:  "Whether the domain is allowed or denied. Must be one of allow or deny",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.DomainTypeAllow, api.DomainTypeDeny}, false),
+			},
+			"kind": {
+				Description:  "Whether the domain is matched exactly or as a regular expression. Must be one of exact or regex",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      api.DomainKindExact,
+				ValidateFunc: validation.StringInSlice([]string{api.DomainKindExact, api.DomainKindRegex}, false),
+			},
+			"comment": {
+				Description: "Comment describing the domain entry",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the domain entry is enabled",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"groups": groupsSchema("IDs of the groups the domain entry applies to. Only the default group 0 is supported when multiple urls are configured"),
+		},
+	}
+}
+
+// domainID returns the Terraform ID of a domain entry
+func domainID(domain *api.Domain) string {
+	return fmt.Sprintf("%s/%s/%s", domain.Type, domain.Kind, domain.Domain)
+}
+
+// parseDomainID splits a domain entry ID into its type, kind and domain
+func parseDomainID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("unexpected domain ID %q, expected type/kind/domain", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+// expandDomain builds a domain entry from the resource configuration
+func expandDomain(d *schema.ResourceData) api.Domain {
+	return api.Domain{
+		Domain:  d.Get("domain").(string),
+		Type:    d.Get("type").(string),
+		Kind:    d.Get("kind").(string),
+		Comment: d.Get("comment").(string),
+		Enabled: d.Get("enabled").(bool),
+		Groups:  expandGroups(d.Get("groups").(*schema.Set)),
+	}
+}
+
+// sameDomain reports whether two domain entries have the same comment, state and groups
+func sameDomain(a *api.Domain, b *api.Domain) bool {
+	groups := schema.NewSet(schema.HashInt, flattenGroups(a.Groups))
+
+	return a.Comment == b.Comment && a.Enabled == b.Enabled && groups.Equal(schema.NewSet(schema.HashInt, flattenGroups(b.Groups)))
+}
+
+// resourceDomainCustomizeDiff rejects configured groups which can't be applied to all instances during planning
+func resourceDomainCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	clients, ok := metaInstances(meta)
+	if !ok || !d.NewValueKnown("groups") {
+		return nil
+	}
+
+	return clients.checkGroups(expandGroups(d.Get("groups").(*schema.Set)))
+}
+
+// resourceDomainCreate handles the creation of an allowed or denied domain via Terraform
+func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domain := expandDomain(d)
+
+	diags := clients.each("Failed to create domain", func(_ int, client *api.Client) error {
+		domainAPI := api.NewDomainAPI(client)
+
+		// Domains already present on some instances of a redundant setup are adopted
+		if clients.multiple() {
+			if _, err := domainAPI.Get(ctx, domain.Type, domain.Kind, domain.Domain); err == nil {
+				_, err = domainAPI.Update(ctx, domain.Type, domain.Kind, domain)
+				return err
+			} else if !errors.Is(err, api.ErrorDomainNotFound) {
+				return err
+			}
+		}
+
+		_, err := domainAPI.Create(ctx, domain)
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(domainID(&domain))
+
+	return resourceDomainRead(ctx, d, meta)
+}
+
+// resourceDomainRead finds an allowed or denied domain based on its type/kind/domain ID
+func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domainType, kind, name, err := parseDomainID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	domains := make([]*api.Domain, len(clients))
+
+	diags := clients.each("Failed to read domain", func(n int, client *api.Client) error {
+		domain, err := api.NewDomainAPI(client).Get(ctx, domainType, kind, name)
+		if errors.Is(err, api.ErrorDomainNotFound) {
+			return nil
+		}
+
+		domains[n] = domain
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	// Domains are compared to the state, or to the first instance's domain after an import. A differing
+	// domain on any instance is written to state so the next apply updates it.
+	var reference *api.Domain
+	if d.Get("domain").(string) != "" {
+		state := expandDomain(d)
+		reference = &state
+	}
+
+	var found *api.Domain
+	missing := false
+	for n, domain := range domains {
+		if domain == nil {
+			missing = true
+			if clients.multiple() {
+				diags = append(diags, driftWarning(clients[n], "Domain %s is missing and will be created", d.Id()))
+			}
+			continue
+		}
+
+		if reference == nil {
+			reference = domain
+		}
+
+		drifted := !sameDomain(domain, reference)
+		if drifted && clients.multiple() {
+			diags = append(diags, driftWarning(clients[n], "Domain %s has comment %q, enabled %t and groups %v", d.Id(), domain.Comment, domain.Enabled, domain.Groups))
+		}
+
+		if found == nil || drifted {
+			found = domain
+		}
+	}
+
+	// A domain missing on any instance is removed from state, so the next apply creates it again
+	if missing {
+		d.SetId("")
+		return diags
+	}
+
+	if err = d.Set("domain", found.Domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("type", found.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("kind", found.Kind); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", found.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("enabled", found.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("groups", flattenAssignedGroups(d, found.Groups)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceDomainUpdate handles in place updates of an allowed or denied domain via Terraform
+func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domainType, kind, _, err := parseDomainID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	domain := expandDomain(d)
+
+	diags := clients.each("Failed to update domain", func(_ int, client *api.Client) error {
+		domainAPI := api.NewDomainAPI(client)
+
+		if clients.multiple() {
+			if _, err := domainAPI.Get(ctx, domainType, kind, domain.Domain); errors.Is(err, api.ErrorDomainNotFound) {
+				_, err = domainAPI.Create(ctx, domain)
+				return err
+			} else if err != nil {
+				return err
+			}
+		}
+
+		_, err := domainAPI.Update(ctx, domainType, kind, domain)
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(domainID(&domain))
+
+	return resourceDomainRead(ctx, d, meta)
+}
+
+// resourceDomainDelete handles the deletion of an allowed or denied domain via Terraform
+func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domainType, kind, name, err := parseDomainID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := clients.each("Failed to delete domain", func(_ int, client *api.Client) error {
+		return api.NewDomainAPI(client).Delete(ctx, domainType, kind, name)
+	})
+
+	if !diags.HasError() {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+// resourceDomainImport validates the type/kind/domain ID of an imported domain
+func resourceDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, _, err := parseDomainID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccDomain acceptance test for the allow/deny domain resource
+func TestAccDomain(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDomainResourceConfig("foo", "foo.com", "allow", "exact", "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "id", "allow/exact/foo.com"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "comment", "first"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "groups.#", "0"),
+					testCheckDomainResourceExists(t, "allow", "exact", "foo.com", "first"),
+					testCheckDomainGroups("allow", "exact", "foo.com", []int64{0}),
+				),
+			},
+			{
+				Config: testDomainResourceConfig("foo", "foo.com", "allow", "exact", "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "comment", "second"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "enabled", "false"),
+					testCheckDomainResourceExists(t, "allow", "exact", "foo.com", "second"),
+				),
+			},
+			{
+				Config: testDomainResourceConfig("foo", "foo.com", "deny", "exact", "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "id", "deny/exact/foo.com"),
+					testCheckDomainResourceExists(t, "deny", "exact", "foo.com", "second"),
+				),
+			},
+			{
+				Config: testDomainWithGroupResourceConfig("foo", "foo.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "groups.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("pihole_domain.foo", "groups.*", "pihole_group.foo", "id"),
+				),
+			},
+			{
+				Config: testDomainResourceConfig("foo", "foo.com", "deny", "exact", "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "groups.#", "0"),
+					testCheckDomainGroups("deny", "exact", "foo.com", []int64{0}),
+				),
+			},
+			{
+				Config: testDomainResourceConfig("regex", `(\\.|^)ads\\.foo\\.com$`, "deny", "regex", "", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.regex", "id", `deny/regex/(\.|^)ads\.foo\.com$`),
+					testCheckDomainResourceExists(t, "deny", "regex", `(\.|^)ads\.foo\.com$`, ""),
+				),
+			},
+			{
+				ResourceName:      "pihole_domain.regex",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testDomainResourceConfig returns HCL to configure an allowed or denied domain
+func testDomainResourceConfig(name string, domain string, domainType string, kind string, comment string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pihole_domain" %q {
+			domain  = "%s"
+			type    = %q
+			kind    = %q
+			comment = %q
+			enabled = %t
+		}
+	`, name, domain, domainType, kind, comment, enabled)
+}
+
+// testDomainWithGroupResourceConfig returns HCL to configure a denied domain only assigned to a new group
+func testDomainWithGroupResourceConfig(name string, domain string) string {
+	return fmt.Sprintf(`
+		resource "pihole_group" %[1]q {
+			name = %[1]q
+		}
+
+		resource "pihole_domain" %[1]q {
+			domain  = %[2]q
+			type    = "deny"
+			comment = "second"
+			enabled = false
+			groups  = [pihole_group.%[1]s.id]
+		}
+	`, name, domain)
+}
+
+// testCheckDomainResourceExists checks that the domain exists in Pi-hole
+func testCheckDomainResourceExists(_ *testing.T, domainType string, kind string, domain string, comment string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(instances)[0]
+
+		record, err := api.NewDomainAPI(client).Get(context.Background(), domainType, kind, domain)
+		if err != nil {
+			return err
+		}
+
+		if record.Comment != comment {
+			return fmt.Errorf("requested %s/%s/%s comment %q does not match: %q", domainType, kind, domain, comment, record.Comment)
+		}
+
+		return nil
+	}
+}
+
+// testCheckDomainGroups checks the groups the domain is assigned to in Pi-hole
+func testCheckDomainGroups(domainType string, kind string, domain string, groups []int64) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		record, err := api.NewDomainAPI(testAccProvider.Meta().(instances)[0]).Get(context.Background(), domainType, kind, domain)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(record.Groups, groups) {
+			return fmt.Errorf("requested %s/%s/%s groups %v do not match: %v", domainType, kind, domain, groups, record.Groups)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckDomainDestroy checks that all domain resources have been deleted
+func testAccCheckDomainDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(instances)[0]
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_domain" {
+			continue
+		}
+
+		domainType, kind, domain, err := parseDomainID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := api.NewDomainAPI(client).Get(context.Background(), domainType, kind, domain); err != nil {
+			if !errors.Is(err, api.ErrorDomainNotFound) {
+				return err
+			}
+		} else {
+			return fmt.Errorf("domain %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestExpandDomainGroups(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{"domain": "foo.com"})
+
+	if groups := expandDomain(d).Groups; !reflect.DeepEqual(groups, []int64{0}) {
+		t.Fatalf("expected unset groups to default to the default group, got %v", groups)
+	}
+
+	if groups := flattenAssignedGroups(d, []int64{0}); len(groups) != 0 {
+		t.Fatalf("expected the implied default group to be left unset, got %v", groups)
+	}
+
+	if groups := flattenAssignedGroups(d, []int64{0, 3}); len(groups) != 2 {
+		t.Fatalf("expected groups other than the default group to be set, got %v", groups)
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceGravityUpdate returns the gravity update Terraform resource management configuration
+func resourceGravityUpdate() *schema.Resource {
+	return &schema.Resource{
+		Description: "Rebuilds the Pi-hole gravity database, e.g. after adlists changed. Gravity runs when the resource is created " +
+			"and whenever its triggers change. The progress is written to the Terraform logs and the apply fails when gravity " +
+			"reports errors. Gravity can take minutes, so request_timeout should not be set too low",
+		CreateContext: resourceGravityUpdateCreate,
+		ReadContext:   resourceGravityUpdateRead,
+		DeleteContext: resourceGravityUpdateDelete,
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Description: "Arbitrary map of values which rebuild gravity when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"updated_at": {
+				Description: "Time gravity was last rebuilt by the resource, in RFC 3339 format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceGravityUpdateCreate rebuilds gravity via Terraform
+func resourceGravityUpdateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	err := api.NewGravityAPI(client).Update(ctx, func(line string) {
+		log.Printf("[INFO] Gravity: %s", line)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	now := time.Now().UTC()
+
+	d.SetId(now.Format(time.RFC3339Nano))
+
+	if err := d.Set("updated_at", now.Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceGravityUpdateRead keeps the state, as gravity runs are not stored by Pi-hole
+func resourceGravityUpdateRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceGravityUpdateDelete removes the gravity update from the state, leaving the gravity database unchanged
+func resourceGravityUpdateDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
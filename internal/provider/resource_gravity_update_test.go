@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccGravityUpdate acceptance test for the gravity update resource
+func TestAccGravityUpdate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testGravityUpdateResourceConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_gravity_update.gravity", "triggers.revision", "1"),
+					resource.TestCheckResourceAttrSet("pihole_gravity_update.gravity", "updated_at"),
+				),
+			},
+			{
+				Config: testGravityUpdateResourceConfig("2"),
+				Check:  resource.TestCheckResourceAttr("pihole_gravity_update.gravity", "triggers.revision", "2"),
+			},
+		},
+	})
+}
+
+// testGravityUpdateResourceConfig returns HCL to configure a gravity update
+func testGravityUpdateResourceConfig(revision string) string {
+	return fmt.Sprintf(`
+		resource "pihole_gravity_update" "gravity" {
+			triggers = {
+				revision = %q
+			}
+		}
+	`, revision)
+}
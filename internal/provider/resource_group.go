@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceGroup returns the group Terraform resource management configuration
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole group",
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Group name",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Group description",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the group is enabled",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+// expandGroup builds a group from the resource configuration
+func expandGroup(d *schema.ResourceData) api.Group {
+	return api.Group{
+		Name:    d.Get("name").(string),
+		Comment: d.Get("description").(string),
+		Enabled: d.Get("enabled").(bool),
+	}
+}
+
+// resourceGroupCreate handles the creation of a group via Terraform
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	group, err := api.NewGroupAPI(client).Create(ctx, expandGroup(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(group.ID, 10))
+
+	return resourceGroupRead(ctx, d, meta)
+}
+
+// resourceGroupRead finds a group based on its numeric ID
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.Errorf("unexpected group ID %q: %s", d.Id(), err)
+	}
+
+	group, err := api.NewGroupAPI(client).Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, api.ErrorGroupNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", group.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("description", group.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("enabled", group.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceGroupUpdate handles in place updates of a group, including renames, via Terraform
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	name, _ := d.GetChange("name")
+
+	if _, err := api.NewGroupAPI(client).Update(ctx, name.(string), expandGroup(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGroupRead(ctx, d, meta)
+}
+
+// resourceGroupDelete handles the deletion of a group via Terraform
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := api.NewGroupAPI(client).Delete(ctx, d.Get("name").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceGroupImport imports a group by its numeric ID or its name
+func resourceGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.ParseInt(d.Id(), 10, 64); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client, ok := metaClient(meta)
+	if !ok {
+		return nil, errors.New("could not load client in resource request")
+	}
+
+	group, err := api.NewGroupAPI(client).GetByName(ctx, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(strconv.FormatInt(group.ID, 10))
+
+	return []*schema.ResourceData{d}, nil
+}
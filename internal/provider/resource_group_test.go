@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccGroup acceptance test for the group resource
+func TestAccGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testGroupResourceConfig("kids", "kids", "Kids devices", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_group.kids", "name", "kids"),
+					resource.TestCheckResourceAttr("pihole_group.kids", "description", "Kids devices"),
+					resource.TestCheckResourceAttr("pihole_group.kids", "enabled", "true"),
+					testCheckGroupResourceExists(t, "kids", true),
+				),
+			},
+			{
+				Config: testGroupResourceConfig("kids", "children", "Children devices", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_group.kids", "name", "children"),
+					resource.TestCheckResourceAttr("pihole_group.kids", "description", "Children devices"),
+					resource.TestCheckResourceAttr("pihole_group.kids", "enabled", "false"),
+					testCheckGroupResourceExists(t, "children", false),
+				),
+			},
+			{
+				ResourceName:      "pihole_group.kids",
+				ImportState:       true,
+				ImportStateId:     "children",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// testGroupResourceConfig returns HCL to configure a group
+func testGroupResourceConfig(name string, group string, description string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pihole_group" %q {
+			name        = %q
+			description = %q
+			enabled     = %t
+		}
+	`, name, group, description, enabled)
+}
+
+// testCheckGroupResourceExists checks that the group exists in Pi-hole
+func testCheckGroupResourceExists(_ *testing.T, name string, enabled bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(instances)[0]
+
+		group, err := api.NewGroupAPI(client).GetByName(context.Background(), name)
+		if err != nil {
+			return err
+		}
+
+		if group.Enabled != enabled {
+			return fmt.Errorf("requested group %s enabled %t does not match: %t", name, enabled, group.Enabled)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckGroupDestroy checks that all group resources have been deleted
+func testAccCheckGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(instances)[0]
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_group" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(r.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		if _, err := api.NewGroupAPI(client).Get(context.Background(), id); err != nil {
+			if !errors.Is(err, api.ErrorGroupNotFound) {
+				return err
+			}
+		} else {
+			return fmt.Errorf("group %s still exists", r.Primary.ID)
+		}
+	}
+
+	return nil
+}
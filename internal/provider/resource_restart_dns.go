@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceRestartDNS returns the DNS resolver restart Terraform resource management configuration
+func resourceRestartDNS() *schema.Resource {
+	return &schema.Resource{
+		Description: "Restarts the Pi-hole DNS resolver, e.g. after configuration changes which require a restart. The resolver restarts " +
+			"when the resource is created and whenever its triggers change, and the apply waits until it is healthy again",
+		CreateContext: resourceRestartDNSCreate,
+		ReadContext:   resourceRestartDNSRead,
+		DeleteContext: resourceRestartDNSDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Description: "Arbitrary map of values which restart the DNS resolver when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"restarted_at": {
+				Description: "Time the DNS resolver was last restarted by the resource, in RFC 3339 format",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// errResolverUnhealthy is retried while waiting for the DNS resolver to be healthy
+var errResolverUnhealthy = errors.New("DNS resolver is not healthy yet")
+
+// resourceRestartDNSCreate restarts the DNS resolver and waits until it is healthy via Terraform
+func resourceRestartDNSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	resolver := api.NewResolverAPI(client)
+
+	// FTL restarts asynchronously, the running process is recorded to wait until it was replaced
+	before, err := resolver.Process(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := resolver.Restart(ctx); err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		healthy, err := resolver.Healthy(ctx, before)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+
+		if !healthy {
+			return retry.RetryableError(errResolverUnhealthy)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("DNS resolver of %s did not become healthy after the restart", client.URL()),
+			Detail:   err.Error(),
+		}}
+	}
+
+	now := time.Now().UTC()
+
+	d.SetId(now.Format(time.RFC3339Nano))
+
+	if err := d.Set("restarted_at", now.Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceRestartDNSRead keeps the state, as restarts are not stored by Pi-hole
+func resourceRestartDNSRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceRestartDNSDelete removes the restart from the state without restarting the DNS resolver
+func resourceRestartDNSDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccRestartDNS acceptance test for the DNS resolver restart resource
+func TestAccRestartDNS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testRestartDNSResourceConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_restart_dns.restart", "triggers.revision", "1"),
+					resource.TestCheckResourceAttrSet("pihole_restart_dns.restart", "restarted_at"),
+				),
+			},
+			{
+				Config: testRestartDNSResourceConfig("2"),
+				Check:  resource.TestCheckResourceAttr("pihole_restart_dns.restart", "triggers.revision", "2"),
+			},
+		},
+	})
+}
+
+// testRestartDNSResourceConfig returns HCL to configure a DNS resolver restart
+func testRestartDNSResourceConfig(revision string) string {
+	return fmt.Sprintf(`
+		resource "pihole_restart_dns" "restart" {
+			triggers = {
+				revision = %q
+			}
+		}
+	`, revision)
+}
@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceReverseServer returns the conditional forwarding Terraform resource management configuration
+func resourceReverseServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages conditional forwarding of a subnet. Reverse lookups of the subnet, and lookups of its local domain, " +
+			"are forwarded to a DNS server such as the router handing out local names",
+		CreateContext: resourceReverseServerCreate,
+		ReadContext:   resourceReverseServerRead,
+		UpdateContext: resourceReverseServerUpdate,
+		DeleteContext: resourceReverseServerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceReverseServerImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"cidr": {
+				Description:  "Subnet in CIDR notation whose reverse lookups are forwarded, e.g. 192.168.10.0/24",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+			},
+			"server": {
+				Description:  "DNS server lookups are forwarded to in ip or ip#port format, e.g. 192.168.10.1",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateServer,
+			},
+			"domain": {
+				Description:  "Local domain whose lookups are forwarded as well, e.g. lan",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(hostnameRegexp, "expected a domain, e.g. lan"),
+			},
+			"enabled": {
+				Description: "Whether lookups are forwarded",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+// validateCIDR validates a subnet in CIDR notation without host bits
+func validateCIDR(v interface{}, _ string) (ws []string, es []error) {
+	if err := api.ValidateCIDR(v.(string)); err != nil {
+		es = append(es, err)
+	}
+
+	return ws, es
+}
+
+// validateServer validates the DNS server of a reverse server in ip or ip#port format
+func validateServer(v interface{}, _ string) (ws []string, es []error) {
+	if err := api.ValidateServer(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("invalid server %q: %w", v, err))
+	}
+
+	return ws, es
+}
+
+// expandReverseServer returns the configured reverse server
+func expandReverseServer(d *schema.ResourceData) api.ReverseServer {
+	return api.ReverseServer{
+		Enabled: d.Get("enabled").(bool),
+		CIDR:    d.Get("cidr").(string),
+		Server:  d.Get("server").(string),
+		Domain:  d.Get("domain").(string),
+	}
+}
+
+// resourceReverseServerCreate handles the creation of a reverse server via Terraform
+func resourceReverseServerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	server := expandReverseServer(d)
+
+	diags := clients.each("Failed to create reverse server", func(_ int, client *api.Client) error {
+		reverseServersAPI := api.NewReverseServersAPI(client)
+
+		// Reverse servers already present on some instances of a redundant setup are adopted
+		if clients.multiple() {
+			_, err := reverseServersAPI.Update(ctx, server)
+			if !errors.Is(err, api.ErrorReverseServerNotFound) {
+				return err
+			}
+		}
+
+		_, err := reverseServersAPI.Create(ctx, server)
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(server.CIDR)
+
+	return append(diags, resourceReverseServerRead(ctx, d, meta)...)
+}
+
+// resourceReverseServerRead retrieves the reverse server of the associated subnet ID
+func resourceReverseServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	servers := make([]*api.ReverseServer, len(clients))
+
+	diags := clients.each("Failed to read reverse server", func(n int, client *api.Client) error {
+		server, err := api.NewReverseServersAPI(client).Get(ctx, d.Id())
+		if errors.Is(err, api.ErrorReverseServerNotFound) {
+			return nil
+		}
+
+		servers[n] = server
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	// Reverse servers are compared to the state, or to the first instance's reverse server after an import.
+	// A differing reverse server on any instance is written to state so the next apply updates it.
+	expected := expandReverseServer(d)
+	if expected.CIDR == "" {
+		for _, server := range servers {
+			if server != nil {
+				expected = *server
+				break
+			}
+		}
+	}
+
+	var found *api.ReverseServer
+	missing := false
+	for n, server := range servers {
+		if server == nil {
+			missing = true
+			if clients.multiple() {
+				diags = append(diags, driftWarning(clients[n], "Reverse server for %s is missing and will be created", d.Id()))
+			}
+			continue
+		}
+
+		drifted := server.Enabled != expected.Enabled || server.Server != expected.Server || server.Domain != expected.Domain
+		if drifted && clients.multiple() {
+			diags = append(diags, driftWarning(clients[n], "Reverse server for %s forwards to %s with domain %q and enabled %t",
+				server.CIDR, server.Server, server.Domain, server.Enabled))
+		}
+
+		if found == nil || drifted {
+			found = server
+		}
+	}
+
+	// A reverse server missing on any instance is removed from state, so the next apply creates it again
+	if missing {
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("cidr", found.CIDR); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("server", found.Server); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("domain", found.Domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("enabled", found.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceReverseServerUpdate handles in place server, domain and enabled changes of a reverse server in a single write
+func resourceReverseServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	server := expandReverseServer(d)
+	server.CIDR = d.Id()
+
+	diags := clients.each("Failed to update reverse server", func(_ int, client *api.Client) error {
+		reverseServersAPI := api.NewReverseServersAPI(client)
+
+		_, err := reverseServersAPI.Update(ctx, server)
+		if errors.Is(err, api.ErrorReverseServerNotFound) && clients.multiple() {
+			_, err = reverseServersAPI.Create(ctx, server)
+		}
+
+		return err
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceReverseServerRead(ctx, d, meta)...)
+}
+
+// resourceReverseServerDelete handles the deletion of a reverse server via Terraform
+func resourceReverseServerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clients, ok := metaInstances(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	diags := clients.each("Failed to delete reverse server", func(_ int, client *api.Client) error {
+		return api.NewReverseServersAPI(client).Delete(ctx, d.Id())
+	})
+
+	if !diags.HasError() {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+// resourceReverseServerImport imports a reverse server by its subnet ID, in the canonical notation of the subnet
+func resourceReverseServerImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if err := api.ValidateCIDR(d.Id()); err != nil {
+		return nil, err
+	}
+
+	_, network, err := net.ParseCIDR(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(network.String())
+
+	return []*schema.ResourceData{d}, nil
+}
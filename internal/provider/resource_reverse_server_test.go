@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// TestAccReverseServer acceptance test for the reverse server resource
+func TestAccReverseServer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckReverseServerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testReverseServerResourceConfig("192.168.77.1/24", "192.168.77.1", "lan", true),
+				ExpectError: regexp.MustCompile("host bits are set"),
+			},
+			{
+				Config:      testReverseServerResourceConfig("192.168.77.0/24", "router.lan", "lan", true),
+				ExpectError: regexp.MustCompile("invalid server"),
+			},
+			{
+				Config: testReverseServerResourceConfig("192.168.77.0/24", "192.168.77.1", "lan", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_reverse_server.vlan", "id", "192.168.77.0/24"),
+					resource.TestCheckResourceAttr("pihole_reverse_server.vlan", "enabled", "true"),
+					testCheckReverseServerExists(api.ReverseServer{Enabled: true, CIDR: "192.168.77.0/24", Server: "192.168.77.1", Domain: "lan"}),
+				),
+			},
+			{
+				Config: testReverseServerResourceConfig("192.168.77.0/24", "192.168.77.53#5353", "iot.lan", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_reverse_server.vlan", "server", "192.168.77.53#5353"),
+					resource.TestCheckResourceAttr("pihole_reverse_server.vlan", "domain", "iot.lan"),
+					resource.TestCheckResourceAttr("pihole_reverse_server.vlan", "enabled", "false"),
+					testCheckReverseServerExists(api.ReverseServer{Enabled: false, CIDR: "192.168.77.0/24", Server: "192.168.77.53#5353", Domain: "iot.lan"}),
+				),
+			},
+			{
+				PreConfig: func() {
+					server := api.ReverseServer{Enabled: true, CIDR: "192.168.77.0/24", Server: "192.168.77.1", Domain: "iot.lan"}
+					if _, err := api.NewReverseServersAPI(testAccProvider.Meta().(instances)[0]).Update(context.Background(), server); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testReverseServerResourceConfig("192.168.77.0/24", "192.168.77.53#5353", "iot.lan", false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testReverseServerResourceConfig("192.168.77.0/24", "192.168.77.53#5353", "iot.lan", false),
+				Check:  testCheckReverseServerExists(api.ReverseServer{Enabled: false, CIDR: "192.168.77.0/24", Server: "192.168.77.53#5353", Domain: "iot.lan"}),
+			},
+			{
+				ResourceName:      "pihole_reverse_server.vlan",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "pihole_reverse_server.vlan",
+				ImportState:   true,
+				ImportStateId: "192.168.77.1/24",
+				ExpectError:   regexp.MustCompile("host bits are set"),
+			},
+		},
+	})
+}
+
+// testReverseServerResourceConfig returns HCL to configure a reverse server
+func testReverseServerResourceConfig(cidr string, server string, domain string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pihole_reverse_server" "vlan" {
+			cidr    = %q
+			server  = %q
+			domain  = %q
+			enabled = %t
+		}
+	`, cidr, server, domain, enabled)
+}
+
+// testCheckReverseServerExists checks that the reverse server exists in Pi-hole
+func testCheckReverseServerExists(expected api.ReverseServer) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		server, err := api.NewReverseServersAPI(testAccProvider.Meta().(instances)[0]).Get(context.Background(), expected.CIDR)
+		if err != nil {
+			return err
+		}
+
+		if *server != expected {
+			return fmt.Errorf("expected reverse server %v, got %v", expected, *server)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckReverseServerDestroy checks that all reverse servers have been deleted
+func testAccCheckReverseServerDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(instances)[0]
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_reverse_server" {
+			continue
+		}
+
+		_, err := api.NewReverseServersAPI(client).Get(context.Background(), r.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("reverse server for %s still exists", r.Primary.ID)
+		}
+
+		if !errors.Is(err, api.ErrorReverseServerNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestResourceReverseServerImport(t *testing.T) {
+	d := resourceReverseServer().TestResourceData()
+	d.SetId("FD00:1:0::/64")
+
+	if _, err := resourceReverseServerImport(context.Background(), d, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "fd00:1::/64" {
+		t.Fatalf("expected the canonical subnet ID, got %s", d.Id())
+	}
+
+	d.SetId("192.168.1.1/24")
+
+	if _, err := resourceReverseServerImport(context.Background(), d, nil); err == nil {
+		t.Fatal("expected subnets with host bits to be rejected")
+	}
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+// resourceTeleporterRestore returns the Teleporter restore Terraform resource management configuration
+func resourceTeleporterRestore() *schema.Resource {
+	return &schema.Resource{
+		Description: "Restores a Pi-hole Teleporter backup archive, e.g. to seed a fresh Pi-hole before other resources manage " +
+			"individual records. The archive is restored when the resource is created and whenever its arguments change. " +
+			"Destroying the resource leaves Pi-hole unchanged",
+		CreateContext: resourceTeleporterRestoreCreate,
+		ReadContext:   resourceTeleporterRestoreRead,
+		DeleteContext: resourceTeleporterRestoreDelete,
+		Schema: map[string]*schema.Schema{
+			"archive_path": {
+				Description:  "Path of the zip archive to restore. Changes of the file content are not detected, add filesha256(path) to triggers to restore them",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"archive_path", "archive_base64"},
+			},
+			"archive_base64": {
+				Description: "Base64 encoded zip archive to restore, e.g. the content_base64 of the pihole_teleporter_backup data source",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+			},
+			"sections": {
+				Description: "Sections of the archive to restore, all sections are restored when unset. Must be any of " + strings.Join(api.TeleporterSections, ", "),
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(api.TeleporterSections, false),
+				},
+			},
+			"triggers": {
+				Description: "Arbitrary map of values which restore the archive again when changed",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"imported_files": {
+				Description: "Files and gravity database tables imported from the archive",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// teleporterRestoreArchive returns the content of the configured archive
+func teleporterRestoreArchive(d *schema.ResourceData) ([]byte, error) {
+	if path := d.Get("archive_path").(string); path != "" {
+		archive, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Teleporter archive: %w", err)
+		}
+
+		return archive, nil
+	}
+
+	archive, err := base64.StdEncoding.DecodeString(d.Get("archive_base64").(string))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Teleporter archive: %w", err)
+	}
+
+	return archive, nil
+}
+
+// resourceTeleporterRestoreCreate restores a Teleporter backup archive via Terraform
+func resourceTeleporterRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := metaClient(meta)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	archive, err := teleporterRestoreArchive(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var sections []string
+	for _, section := range d.Get("sections").(*schema.Set).List() {
+		sections = append(sections, section.(string))
+	}
+
+	files, err := api.NewTeleporterAPI(client).Restore(ctx, archive, sections)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(time.Now().UTC().Format(time.RFC3339Nano))
+
+	if err := d.Set("imported_files", files); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceTeleporterRestoreRead keeps the state, as restores are not stored by Pi-hole
+func resourceTeleporterRestoreRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceTeleporterRestoreDelete removes the restore from the state, leaving Pi-hole unchanged
+func resourceTeleporterRestoreDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
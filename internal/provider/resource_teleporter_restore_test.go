@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccTeleporterRestore acceptance test for the Teleporter restore resource
+func TestAccTeleporterRestore(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "pihole_teleporter_backup" "backup" {}
+
+					resource "pihole_teleporter_restore" "restore" {
+					  archive_base64 = data.pihole_teleporter_backup.backup.content_base64
+					  sections       = ["gravity.group"]
+
+					  lifecycle {
+					    ignore_changes = [archive_base64]
+					  }
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("pihole_teleporter_restore.restore", "imported_files.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/ryanwholey/terraform-provider-pihole/internal/api"
+)
+
+var (
+	clientsMu sync.Mutex
+	clients   []*api.Client
+)
+
+// registerClient tracks a configured client so its session can be logged out on shutdown
+func registerClient(client *api.Client) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	clients = append(clients, client)
+}
+
+// Shutdown logs out the sessions created by the configured clients, freeing Pi-hole's session slots
+func Shutdown(ctx context.Context) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	for _, client := range clients {
+		if err := client.Logout(ctx); err != nil {
+			log.Printf("[WARN] Failed to logout of Pi-hole: %s", err)
+		}
+	}
+
+	clients = nil
+}
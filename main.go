@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/ryanwholey/terraform-provider-pihole/internal/provider"
 )
@@ -9,4 +12,10 @@ func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: provider.Provider,
 	})
+
+	// Terraform waits a short moment for the plugin to exit after stopping it
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	provider.Shutdown(ctx)
 }